@@ -0,0 +1,172 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 将 .zip 或 .tar.gz (.tgz) 源码包解压到临时目录，返回其中 go.mod 所在的目录和临时目录
+func extractArchive(file string) (root, tmpDir string, err error) {
+	tmpDir, err = os.MkdirTemp("", "check_deps-")
+	if err != nil {
+		return "", "", err
+	}
+
+	name := strings.ToLower(file)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		err = extractZip(file, tmpDir)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		err = extractTarGz(file, tmpDir)
+	default:
+		err = fmt.Errorf("不支持的压缩包格式: %s (支持 .zip、.tar.gz、.tgz)", file)
+	}
+	if err == nil {
+		root, err = findModuleRoot(tmpDir)
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", "", err
+	}
+	return root, tmpDir, nil
+}
+
+// 计算压缩包内条目的解压路径，拒绝绝对路径和跳出目标目录的路径
+func archiveTarget(dest, name string) (string, error) {
+	target := filepath.Join(dest, filepath.FromSlash(name))
+	if rel, err := filepath.Rel(dest, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("压缩包中包含非法路径: %s", name)
+	}
+	return target, nil
+}
+
+// 将内容写入文件，自动创建父目录
+func writeArchiveFile(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// 解压 zip，只保留普通文件和目录
+func extractZip(file, dest string) error {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		target, err := archiveTarget(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 解压 tar.gz
+func extractTarGz(file, dest string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	return extractTar(gz, dest)
+}
+
+// 解压 tar 流，只保留普通文件和目录 (忽略符号链接等)
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := archiveTarget(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// 查找层级最浅的 go.mod 所在目录，跳过 vendor 和 testdata
+func findModuleRoot(dir string) (string, error) {
+	root, depth := "", -1
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && (d.Name() == "vendor" || d.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "go.mod" {
+			return nil
+		}
+		if n := strings.Count(p, string(filepath.Separator)); depth < 0 || n < depth {
+			root, depth = filepath.Dir(p), n
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if root == "" {
+		return "", fmt.Errorf("压缩包中没有 go.mod")
+	}
+	return root, nil
+}
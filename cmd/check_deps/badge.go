@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// shields.io flat 风格徽章的模板，参数依次为: 总宽度、标签宽度、信息宽度、信息颜色、
+// 标签文字中心、标签文字、信息文字中心、信息文字
+const badgeTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[6]s: %[8]s">
+  <title>%[6]s: %[8]s</title>
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%[2]d" height="20" fill="#555"/>
+    <rect x="%[2]d" width="%[3]d" height="20" fill="%[4]s"/>
+    <rect width="%[1]d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%[5]d" y="15" fill="#010101" fill-opacity=".3">%[6]s</text>
+    <text x="%[5]d" y="14">%[6]s</text>
+    <text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[8]s</text>
+    <text x="%[7]d" y="14">%[8]s</text>
+  </g>
+</svg>
+`
+
+// 按 Verdana 11px 的平均字宽估算文字宽度，两侧各留 5px
+func badgeTextWidth(s string) int {
+	return utf8.RuneCountInString(s)*7 + 10
+}
+
+// 按健康度评分选择信息部分的颜色
+func badgeColor(score float64) string {
+	switch {
+	case score >= 80:
+		return "#4c1"
+	case score >= 60:
+		return "#dfb317"
+	default:
+		return "#e05d44"
+	}
+}
+
+// 生成依赖徽章的 SVG，如 "deps: 42 | health: 87"
+func renderBadge(deps int, score float64) string {
+	label := "deps"
+	message := fmt.Sprintf("%d | health: %.0f", deps, score)
+	lw, mw := badgeTextWidth(label), badgeTextWidth(message)
+	return fmt.Sprintf(badgeTemplate, lw+mw, lw, mw, badgeColor(score),
+		lw/2, html.EscapeString(label), lw+mw/2, html.EscapeString(message))
+}
+
+// 将外部依赖数量和健康度评分写入 SVG 徽章，自动创建父目录
+func (da *DependencyAnalyzer) writeBadge(file string, weights map[string]float64) error {
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	svg := renderBadge(len(da.externalPackages()), da.computeScore(weights).Total)
+	return os.WriteFile(file, []byte(svg), 0644)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 单个分类相对基线的变化
+type categoryDiff struct {
+	Name    string
+	Added   []string
+	Removed []string
+}
+
+// 对比当前结果与基线，返回各分类新增和移除的包
+func diffReports(baseline, current *Report) []categoryDiff {
+	pairs := []struct {
+		name     string
+		old, new []string
+	}{
+		{"标准库", baseline.Stdlib, current.Stdlib},
+		{"扩展库", baseline.Extended, current.Extended},
+		{"第三方库", baseline.ThirdParty, current.ThirdParty},
+		{"内部包", baseline.Internal, current.Internal},
+	}
+	// 自定义分类按名称排序，基线或当前结果中任一存在即参与对比
+	names := make(map[string]bool)
+	for name := range baseline.Custom {
+		names[name] = true
+	}
+	for name := range current.Custom {
+		names[name] = true
+	}
+	for _, name := range sortedKeys(names) {
+		pairs = append(pairs, struct {
+			name     string
+			old, new []string
+		}{name, baseline.Custom[name], current.Custom[name]})
+	}
+
+	var diffs []categoryDiff
+	for _, p := range pairs {
+		d := categoryDiff{Name: p.name, Added: subtract(p.new, p.old), Removed: subtract(p.old, p.new)}
+		if len(d.Added) > 0 || len(d.Removed) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// 返回在 a 中但不在 b 中的元素，保持 a 的顺序
+func subtract(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, s := range b {
+		set[s] = true
+	}
+	var result []string
+	for _, s := range a {
+		if !set[s] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// 打印基线对比结果
+func printBaselineDiff(file string, diffs []categoryDiff) {
+	fmt.Println()
+	if len(diffs) == 0 {
+		fmt.Printf("✅ 依赖与基线一致: %s\n", file)
+		return
+	}
+	fmt.Printf("❌ 依赖与基线不一致: %s\n", file)
+	for _, d := range diffs {
+		fmt.Printf("%s:\n", d.Name)
+		for _, pkg := range d.Added {
+			fmt.Printf("  + %s\n", pkg)
+		}
+		for _, pkg := range d.Removed {
+			fmt.Printf("  - %s\n", pkg)
+		}
+	}
+	fmt.Println("提示: 确认变化后可使用 -baseline-update 更新基线")
+}
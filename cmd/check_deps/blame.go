@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// git blame 对一行导入的归属
+type blameInfo struct {
+	Commit string
+	Author string
+	Time   time.Time
+	Site   importSite
+}
+
+// 未提交的行在 git blame 中的提交号
+const uncommittedHash = "0000000000000000000000000000000000000000"
+
+// 用 git blame --porcelain 查询一行的作者和提交
+func (da *DependencyAnalyzer) blameLine(file string, line int) (blameInfo, error) {
+	out, err := da.git("blame", "--porcelain", "-L", fmt.Sprintf("%d,%d", line, line), "--", file)
+	if err != nil {
+		return blameInfo{}, err
+	}
+	var info blameInfo
+	for i, l := range strings.Split(string(out), "\n") {
+		switch {
+		case i == 0:
+			if fields := strings.Fields(l); len(fields) > 0 {
+				info.Commit = fields[0]
+			}
+		case strings.HasPrefix(l, "author "):
+			info.Author = strings.TrimPrefix(l, "author ")
+		case strings.HasPrefix(l, "author-time "):
+			if sec, err := strconv.ParseInt(strings.TrimPrefix(l, "author-time "), 10, 64); err == nil {
+				info.Time = time.Unix(sec, 0)
+			}
+		}
+	}
+	return info, nil
+}
+
+// 对每个外部依赖的所有导入位置执行 git blame，取最早的一次作为引入者
+// 未纳入 git 的文件无法 blame，跳过对应的导入位置
+func (da *DependencyAnalyzer) blameDependencies() (map[string]blameInfo, error) {
+	if _, err := da.git("rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil, err
+	}
+	result := make(map[string]blameInfo)
+	for _, pkg := range da.externalPackages() {
+		for _, site := range da.sites[pkg] {
+			info, err := da.blameLine(site.File, site.Line)
+			if err != nil {
+				continue
+			}
+			info.Site = site
+			if prev, ok := result[pkg]; !ok || info.Time.Before(prev.Time) {
+				result[pkg] = info
+			}
+		}
+	}
+	return result, nil
+}
+
+// 打印每个外部依赖的引入者
+func (da *DependencyAnalyzer) printBlame(blames map[string]blameInfo, opts printOptions) {
+	printSectionHeader("依赖引入者", opts.quiet)
+	for _, pkg := range da.externalPackages() {
+		info, ok := blames[pkg]
+		if !ok {
+			fmt.Printf("  %-50s 无法获取 (导入它的文件未纳入 git)\n", pkg)
+			continue
+		}
+		where := fmt.Sprintf("%s:%d", da.relPath(info.Site.File), info.Site.Line)
+		if info.Commit == uncommittedHash {
+			fmt.Printf("  %-50s 未提交  %s\n", pkg, where)
+			continue
+		}
+		fmt.Printf("  %-50s %s  %s  %s  %s\n", pkg, info.Commit[:min(8, len(info.Commit))], info.Time.Format("2006-01-02"), info.Author, where)
+	}
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 两个 git 引用之间第三方模块的变化
+type moduleChange struct {
+	Path       string
+	OldVersion string // 新增的模块为空
+	NewVersion string // 移除的模块为空
+}
+
+// 分支对比的结果
+type branchDiff struct {
+	Base, Head string
+	Added      []moduleChange
+	Removed    []moduleChange
+	Changed    []moduleChange // 版本变化
+}
+
+// 解析 <base>..<head>
+func parseBranchRange(s string) (string, string, error) {
+	base, head, ok := strings.Cut(s, "..")
+	base, head = strings.TrimSpace(base), strings.TrimSpace(head)
+	if !ok || base == "" || head == "" || strings.HasPrefix(head, ".") {
+		return "", "", fmt.Errorf("-branch-diff 的格式应为 <base>..<head>，如 main..feature")
+	}
+	return base, head, nil
+}
+
+// 用 git archive 将引用处的项目目录导出到临时目录，返回导出的目录
+func (da *DependencyAnalyzer) exportRef(ref string) (string, error) {
+	out, err := da.git("rev-parse", "--show-prefix")
+	if err != nil {
+		return "", err
+	}
+	treeish := ref
+	if prefix := strings.TrimSuffix(strings.TrimSpace(string(out)), "/"); prefix != "" {
+		treeish += ":" + prefix
+	}
+
+	dir, err := os.MkdirTemp("", "check_deps-")
+	if err != nil {
+		return "", err
+	}
+	atExit(func() { os.RemoveAll(dir) })
+
+	cmd := exec.Command("git", "archive", "--format=tar", treeish)
+	cmd.Dir = da.projectPath
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	extractErr := extractTar(stdout, dir)
+	if err := cmd.Wait(); err != nil {
+		return "", fmt.Errorf("git archive %s: %s", treeish, strings.TrimSpace(stderr.String()))
+	}
+	if extractErr != nil {
+		return "", extractErr
+	}
+	return dir, nil
+}
+
+// 深度分析引用处整个模块的所有 .go 文件 (不含 vendor、testdata 和嵌套模块)，返回实际导入的第三方模块及其版本
+func (da *DependencyAnalyzer) modulesAtRef(ref string) (map[string]string, error) {
+	dir, err := da.exportRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	n := da.withConfig(dir)
+	entries, err := n.projectFiles()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", ref, err)
+	}
+	if err := n.analyzeDependencies(entries, true); err != nil {
+		return nil, fmt.Errorf("%s: %v", ref, err)
+	}
+	modules := make(map[string]string)
+	for _, m := range n.usedModules("name") {
+		modules[m.Path] = m.Version
+	}
+	return modules, nil
+}
+
+// 对比两个引用处整个模块实际导入的第三方模块: 新增、移除和版本变化
+func (da *DependencyAnalyzer) analyzeBranchDiff(base, head string) (*branchDiff, error) {
+	before, err := da.modulesAtRef(base)
+	if err != nil {
+		return nil, err
+	}
+	after, err := da.modulesAtRef(head)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &branchDiff{Base: base, Head: head}
+	for path, newVersion := range after {
+		oldVersion, ok := before[path]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, moduleChange{Path: path, NewVersion: newVersion})
+		case oldVersion != newVersion:
+			diff.Changed = append(diff.Changed, moduleChange{Path: path, OldVersion: oldVersion, NewVersion: newVersion})
+		}
+	}
+	for path, oldVersion := range before {
+		if _, ok := after[path]; !ok {
+			diff.Removed = append(diff.Removed, moduleChange{Path: path, OldVersion: oldVersion})
+		}
+	}
+	for _, list := range [][]moduleChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	}
+	return diff, nil
+}
+
+// 比较两个语义化版本的主、次、修订号，无法解析时返回 0
+func compareVersions(a, b string) int {
+	pa, oka := parseVersionNumbers(a)
+	pb, okb := parseVersionNumbers(b)
+	if !oka || !okb {
+		return 0
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	// 数字相同时，预发布版本 (含伪版本) 低于正式版本，预发布版本之间按字符串比较
+	preA, preB := versionPrerelease(a), versionPrerelease(b)
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	case preA < preB:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// 解析 vX.Y.Z 中的数字
+func parseVersionNumbers(v string) ([3]int, bool) {
+	var nums [3]int
+	core, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), "+")
+	core, _, _ = strings.Cut(core, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return nums, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nums, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
+
+// 版本中的预发布部分 (- 之后、+ 之前)
+func versionPrerelease(v string) string {
+	core, _, _ := strings.Cut(v, "+")
+	_, pre, _ := strings.Cut(core, "-")
+	return pre
+}
+
+// 未在 go.mod 中 require 的模块显示为 (未 require)
+func displayVersion(v string) string {
+	if v == "" {
+		return "(未 require)"
+	}
+	return v
+}
+
+// 打印两个引用之间第三方模块的变化
+func printBranchDiff(diff *branchDiff) {
+	fmt.Printf("\n%s..%s 第三方模块变化:\n", diff.Base, diff.Head)
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) == 0 {
+		fmt.Println("✅ 没有变化")
+		return
+	}
+	if len(diff.Added) > 0 {
+		fmt.Printf("➕ 新增 (%d):\n", len(diff.Added))
+		for _, c := range diff.Added {
+			fmt.Printf("  %s %s\n", c.Path, displayVersion(c.NewVersion))
+		}
+	}
+	if len(diff.Removed) > 0 {
+		fmt.Printf("➖ 移除 (%d):\n", len(diff.Removed))
+		for _, c := range diff.Removed {
+			fmt.Printf("  %s %s\n", c.Path, displayVersion(c.OldVersion))
+		}
+	}
+	if len(diff.Changed) > 0 {
+		fmt.Printf("🔄 版本变化 (%d):\n", len(diff.Changed))
+		for _, c := range diff.Changed {
+			kind := "变更"
+			switch compareVersions(c.OldVersion, c.NewVersion) {
+			case -1:
+				kind = "升级"
+			case 1:
+				kind = "降级"
+			}
+			fmt.Printf("  %s %s -> %s (%s)\n", c.Path, displayVersion(c.OldVersion), displayVersion(c.NewVersion), kind)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// 分别分析每个入口文件并打印各自的分类结果，入口之间的结果互不合并
+func (da *DependencyAnalyzer) printByEntry(entries []string, deep bool, matchRe *regexp.Regexp, opts printOptions) error {
+	sub := opts
+	sub.quiet = true
+	sub.statsOnly = false
+	for _, entry := range entries {
+		n := da.withConfig(da.projectPath)
+		n.buildCtx = da.buildCtx
+		n.stdinFile, n.stdinSrc = da.stdinFile, da.stdinSrc
+		if err := n.analyzeDependencies([]string{entry}, deep); err != nil {
+			return err
+		}
+		if matchRe != nil {
+			n.keepMatching(matchRe)
+		}
+
+		title := "入口: " + da.relPath(entry)
+		if opts.quiet {
+			fmt.Printf("\n%s\n", title)
+		} else {
+			printSectionHeader(title, false)
+		}
+		n.printResults(sub)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 自定义分类规则，优先于内置的分类逻辑
+//
+// 规则文件格式，每行一条，# 开头为注释:
+//
+//	github.com/acme -> partner
+//	gitlab.corp.com/* -> internal
+//	github.com/gogo/protobuf -> legacy
+//
+// 模式按 -exclude 的规则匹配包路径本身或其父路径，按文件中的顺序取第一条匹配的规则。
+// 分类可以是内置分类 (stdlib、third-party、internal，-split-xtools 时还有 extended)，
+// 其他名称会作为新的分类，按在文件中首次出现的顺序排在第三方库之后展示。
+// 深度分析按规则的结果决定是否进入包: 归入 internal 的本地包会被分析，归入其他分类的包不再进入。
+type classifyRule struct {
+	Pattern  string
+	Category string
+}
+
+// 自定义分类的图标
+const customCategoryIcon = "🏷️"
+
+// 内置分类的名称
+var builtinCategories = map[string]bool{
+	"stdlib":      true,
+	"extended":    true,
+	"third-party": true,
+	"internal":    true,
+	"cgo":         true,
+}
+
+// 读取自定义分类规则文件
+func loadClassifyRules(file string) ([]classifyRule, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var rules []classifyRule
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+		pattern, category, ok := strings.Cut(line, "->")
+		pattern, category = strings.TrimSpace(pattern), strings.TrimSpace(category)
+		if !ok || pattern == "" || category == "" {
+			return nil, fmt.Errorf("第 %d 行: 格式应为 \"<包路径模式> -> <分类>\"", i+1)
+		}
+		if category == "cgo" {
+			return nil, fmt.Errorf("第 %d 行: 不能归入 cgo 分类", i+1)
+		}
+		rules = append(rules, classifyRule{Pattern: strings.TrimSuffix(pattern, "/"), Category: category})
+	}
+	return rules, nil
+}
+
+// 规则中引入的新分类，按首次出现的顺序
+func customCategories(rules []classifyRule) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range rules {
+		if !builtinCategories[r.Category] && !seen[r.Category] {
+			seen[r.Category] = true
+			names = append(names, r.Category)
+		}
+	}
+	return names
+}
+
+// 设置自定义分类规则，为新分类创建包集合
+func (da *DependencyAnalyzer) setClassifyRules(rules []classifyRule) {
+	da.classifyRules = rules
+	for _, name := range customCategories(rules) {
+		da.customCats = append(da.customCats, categoryInfo{Key: name, Title: name, Icon: customCategoryIcon})
+		da.custom[name] = make(map[string]bool)
+	}
+}
+
+// 按自定义规则判断包的分类
+func (da *DependencyAnalyzer) matchClassifyRule(pkg string) (string, bool) {
+	for _, r := range da.classifyRules {
+		if matchPathPattern(r.Pattern, pkg) {
+			return r.Category, true
+		}
+	}
+	return "", false
+}
+
+// 不属于标准库和内部包的外部依赖: 第三方库、扩展库以及自定义分类中的外部包，按包名排序
+func (da *DependencyAnalyzer) externalPackages() []string {
+	set := make(map[string]bool)
+	for _, key := range []string{"third-party", "extended"} {
+		for pkg := range da.categorySet(key) {
+			set[pkg] = true
+		}
+	}
+	for _, custom := range da.custom {
+		for pkg := range custom {
+			if !da.isStdLib(pkg) && !da.isInternalPkg(pkg) {
+				set[pkg] = true
+			}
+		}
+	}
+	return sortedKeys(set)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 库包导入命令包 (package main 或 cmd/ 目录下的包) 的导入
+type commandImport struct {
+	From, To string
+	Site     importSite
+}
+
+// 判断内部包是否是命令包: package main 或位于 cmd/ 目录下
+func (da *DependencyAnalyzer) isCommandPkg(pkg string) bool {
+	if da.pkgNames[pkg] == "main" {
+		return true
+	}
+	rel := strings.TrimPrefix(strings.TrimPrefix(pkg, da.goModPath), "/")
+	for _, seg := range strings.Split(rel, "/") {
+		if seg == "cmd" {
+			return true
+		}
+	}
+	return false
+}
+
+// 查找库包导入命令包的内部导入
+func (da *DependencyAnalyzer) findCommandImports() []commandImport {
+	var found []commandImport
+	for from, tos := range da.edges {
+		if !da.isInternalPkg(from) || da.isCommandPkg(from) {
+			continue
+		}
+		for to := range tos {
+			if !da.isInternalPkg(to) || !da.isCommandPkg(to) || da.ignoredEdge(from, to) {
+				continue
+			}
+			site, _ := da.findSite(from, to)
+			found = append(found, commandImport{From: from, To: to, Site: site})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].From != found[j].From {
+			return found[i].From < found[j].From
+		}
+		return found[i].To < found[j].To
+	})
+	return found
+}
+
+// 打印库包导入命令包的问题
+func (da *DependencyAnalyzer) printCommandImports(found []commandImport) {
+	fmt.Println()
+	fmt.Printf("⚠️  库包导入了命令包 (package main 或 cmd/ 下的包) (%d):\n", len(found))
+	for _, c := range found {
+		fmt.Printf("  %s -> %s\n", c.From, c.To)
+		if c.Site.File != "" {
+			fmt.Printf("    %s:%d\n", da.relPath(c.Site.File), c.Site.Line)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+)
+
+// ANSI 颜色码
+const (
+	colorReset  = "\033[0m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+	colorCyan   = "\033[36m"
+)
+
+// 根据 -color 参数判断是否输出颜色: always | never | auto (设置了 NO_COLOR 或 stdout 不是终端时关闭)
+func useColor(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(os.Stdout)
+}
+
+// 判断文件是否是终端
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// 为文本添加颜色
+func colorize(s, color string, enabled bool) string {
+	if !enabled || color == "" {
+		return s
+	}
+	return color + s + colorReset
+}
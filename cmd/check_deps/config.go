@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 默认配置文件名，位于项目根目录
+const defaultConfigFile = ".depcheck.yaml"
+
+// 配置文件中与命令行参数名不同的键
+//
+// 配置文件格式 (YAML 子集，只支持顶层键值和字符串列表):
+//
+//	deep: true                  # 同 -d
+//	verbose: false              # 同 -v
+//	type: third-party           # 同 -type，也可写作 filterType
+//	exclude:                    # 同 -exclude，列表或逗号分隔的字符串
+//	  - github.com/gogo/*
+//	internal-prefix: example.com/proj  # 同 -internal-prefix
+//	max-third-party: 50         # 同 -max-third-party
+//	max-total: 200              # 同 -max-total
+//
+// 其他键名与命令行参数名 (不带 -) 相同。命令行参数优先于配置文件。
+var configAliases = map[string]string{
+	"deep":       "d",
+	"verbose":    "v",
+	"filterType": "type",
+}
+
+// 解析配置文件，列表值以逗号连接
+func parseConfig(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	listKey := ""
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := stripYAMLComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// 缩进的 "- item" 属于上一个键的列表
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if listKey == "" {
+				return nil, fmt.Errorf("第 %d 行: 列表项缺少所属的键", i+1)
+			}
+			item := unquoteYAML(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if values[listKey] == "" {
+				values[listKey] = item
+			} else {
+				values[listKey] += "," + item
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok || line[0] == ' ' || line[0] == '\t' {
+			return nil, fmt.Errorf("第 %d 行: 无法解析 %q", i+1, trimmed)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		listKey = ""
+
+		switch {
+		case value == "":
+			// 值在后续的列表项中
+			listKey = key
+			values[key] = ""
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			var items []string
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = unquoteYAML(strings.TrimSpace(item)); item != "" {
+					items = append(items, item)
+				}
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = unquoteYAML(value)
+		}
+	}
+	return values, nil
+}
+
+// 去除行内 # 注释 (引号内的 # 保留)
+func stripYAMLComment(line string) string {
+	inQuote := byte(0)
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case inQuote != 0:
+			if c == inQuote {
+				inQuote = 0
+			}
+		case c == '"' || c == '\'':
+			inQuote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t\r")
+}
+
+// 去除字符串两端的引号
+func unquoteYAML(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// 读取配置文件并应用到命令行未显式设置的参数上
+func applyConfig(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	values, err := parseConfig(data)
+	if err != nil {
+		return err
+	}
+
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for key, value := range values {
+		name := key
+		if alias, ok := configAliases[key]; ok {
+			name = alias
+		}
+		if flag.Lookup(name) == nil || name == "config" {
+			return fmt.Errorf("未知的配置项 %q", key)
+		}
+		if explicit[name] {
+			continue
+		}
+		if err := flag.Set(name, value); err != nil {
+			return fmt.Errorf("配置项 %s 的值 %q 无效: %v", key, value, err)
+		}
+	}
+	return nil
+}
+
+// 判断文件是否存在
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// 以 D2 输出内部包关系: 每个顶层目录为一个容器，包为其中的节点，边为包之间的导入
+func (da *DependencyAnalyzer) writeD2(w io.Writer, entries []string, opts printOptions) error {
+	dirs, members, _ := da.dirGraph(entries)
+	_, graph := da.internalGraph(entries)
+	ref := func(pkg string) string {
+		return strconv.Quote(da.topLevelDir(pkg)) + "." + strconv.Quote(da.shortPkg(pkg))
+	}
+
+	fmt.Fprintln(w, "direction: down")
+	for _, dir := range dirs {
+		fmt.Fprintf(w, "%s: {\n", strconv.Quote(dir))
+		for _, pkg := range members[dir] {
+			fmt.Fprintf(w, "  %s\n", strconv.Quote(da.shortPkg(pkg)))
+		}
+		fmt.Fprintln(w, "}")
+	}
+	for _, dir := range dirs {
+		for _, from := range members[dir] {
+			for _, to := range sortedKeys(graph[from]) {
+				fmt.Fprintf(w, "%s -> %s\n", ref(from), ref(to))
+			}
+		}
+	}
+	return nil
+}
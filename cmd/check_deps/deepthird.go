@@ -0,0 +1,61 @@
+package main
+
+// 判断是否是需要 -deep-third-party 递归的外部包 (第三方库、扩展库和自定义分类中的外部包)
+func (da *DependencyAnalyzer) isExternalPkg(pkg string) bool {
+	return pkg != "C" && !da.isStdLib(pkg) && !da.isInternalPkg(pkg)
+}
+
+// 判断文件是否来自第三方包的源码 (-deep-third-party 时从模块缓存中找到)
+func (da *DependencyAnalyzer) isThirdPartyFile(file string) bool {
+	_, ok := da.extFiles[file]
+	return ok
+}
+
+// 判断包是否只通过第三方包传递引入: 所有导入位置都在第三方包的源码中
+func (da *DependencyAnalyzer) isTransitive(pkg string) bool {
+	return len(da.sites[pkg]) == 0 && len(da.extSites[pkg]) > 0
+}
+
+// 包最早的导入位置，只通过第三方包引入时取第三方包源码中的位置
+func (da *DependencyAnalyzer) firstSite(pkg string) (importSite, bool) {
+	if sites := da.sites[pkg]; len(sites) > 0 {
+		return sites[0], true
+	}
+	if sites := da.extSites[pkg]; len(sites) > 0 {
+		return sites[0], true
+	}
+	return importSite{}, false
+}
+
+// 进入第三方包: 在模块缓存 (或 replace 指向的本地目录) 中找到包的源码，返回需要继续分析的文件。
+// 找不到 go.mod 中的版本或源码时只记录在 -trace 中，不视为错误
+func (da *DependencyAnalyzer) followThirdParty(pkg string, level int) []fileTask {
+	if da.followed[pkg] {
+		return nil
+	}
+	da.followed[pkg] = true
+	if da.maxDepth > 0 && level+1 > da.maxDepth {
+		da.tracef(level, "  %s: 超过 -max-depth %d，不再深入", pkg, da.maxDepth)
+		return nil
+	}
+	dir, ok := da.externalPkgDir(pkg)
+	if !ok {
+		da.tracef(level, "  %s: go.mod 中没有所属模块的版本，无法定位源码", pkg)
+		return nil
+	}
+	files := da.buildFiles(goFilesInDir(dir, false))
+	if len(files) == 0 {
+		da.tracef(level, "  %s: 模块缓存中没有源码 (可先执行 go mod download)", pkg)
+		return nil
+	}
+	da.tracef(level, "  进入第三方包 %s (%d 个文件，第 %d 层)", pkg, len(files), level+1)
+	var tasks []fileTask
+	for _, file := range files {
+		if key := realPath(file); !da.visited[key] {
+			da.visited[key] = true
+			da.extFiles[file] = pkg
+			tasks = append(tasks, fileTask{file: file, level: level + 1})
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 已弃用或冻结的标准库包及建议的替代方案，新增条目直接加在这里
+var deprecatedStdlib = map[string]string{
+	"io/ioutil":       "使用 io 和 os 中的同名函数 (Go 1.16 起弃用)",
+	"crypto/dsa":      "DSA 已不安全，使用 crypto/ed25519 或 crypto/ecdsa",
+	"crypto/elliptic": "底层曲线运算已弃用，ECDH 使用 crypto/ecdh，签名使用 crypto/ecdsa",
+	"net/rpc":         "已冻结，使用 gRPC 等维护中的 RPC 框架",
+	"net/rpc/jsonrpc": "已冻结，使用 gRPC 等维护中的 RPC 框架",
+}
+
+// 查找使用了的弃用标准库，按包名排序
+func (da *DependencyAnalyzer) findDeprecated() []string {
+	var pkgs []string
+	for pkg := range da.stdlib {
+		if _, ok := deprecatedStdlib[pkg]; ok && !da.onlyIgnored(pkg) {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return sortedKeys(toSet(pkgs))
+}
+
+// 打印弃用标准库的使用情况和替代建议
+func (da *DependencyAnalyzer) printDeprecated(pkgs []string, verbose bool) {
+	fmt.Println()
+	fmt.Printf("⚠️  使用了弃用的标准库 (%d):\n", len(pkgs))
+	for _, pkg := range pkgs {
+		fmt.Printf("  %s → %s\n", pkg, deprecatedStdlib[pkg])
+		if verbose {
+			for _, site := range da.sites[pkg] {
+				fmt.Printf("    %s:%d\n", da.relPath(site.File), site.Line)
+			}
+		}
+	}
+}
+
+// 将列表转换为集合
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
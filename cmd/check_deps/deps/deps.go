@@ -0,0 +1,95 @@
+// Package deps 只根据导入路径将 Go 包分为标准库、第三方库、内部包和 cgo 伪包，
+// 不解析文件也不访问文件系统。check_deps 的分类基于这里的规则，
+// 已经拿到导入列表的其他工具也可以直接复用。
+package deps
+
+import (
+	"sort"
+	"strings"
+)
+
+// 分类名称，与 check_deps -type 的取值一致
+const (
+	Stdlib     = "stdlib"
+	ThirdParty = "third-party"
+	Internal   = "internal"
+	Cgo        = "cgo"
+)
+
+// IsStdlib 判断是否是标准库: 第一段路径不包含点号 (如 fmt、net/http)
+func IsStdlib(pkg string) bool {
+	return !strings.Contains(strings.Split(pkg, "/")[0], ".")
+}
+
+// HasPathPrefix 判断 pkg 是否是 prefix 本身或其子包，按路径段匹配，
+// example.com/app 不匹配 example.com/application
+func HasPathPrefix(pkg, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix != "" && (pkg == prefix || strings.HasPrefix(pkg, prefix+"/"))
+}
+
+// Classify 返回导入路径的分类。internal 为内部包的模块路径或前缀 (通常取自 go.mod)，
+// 为空时不会有包归为内部包；与 check_deps 相同，标准库的判断优先
+func Classify(pkg, internal string) string {
+	switch {
+	case pkg == "C":
+		return Cgo
+	case IsStdlib(pkg):
+		return Stdlib
+	case HasPathPrefix(pkg, internal):
+		return Internal
+	default:
+		return ThirdParty
+	}
+}
+
+// Result 是导入路径列表的分类结果，字段与 check_deps -format json 报告中的同名字段一致
+type Result struct {
+	Stdlib     []string `json:"stdlib"`
+	ThirdParty []string `json:"thirdParty"`
+	Internal   []string `json:"internal"`
+	Cgo        bool     `json:"cgo,omitempty"`
+	Stats      Stats    `json:"stats"`
+}
+
+// Stats 是各分类的包数量 (不含 cgo)
+type Stats struct {
+	Total      int `json:"total"`
+	Stdlib     int `json:"stdlib"`
+	ThirdParty int `json:"thirdParty"`
+	Internal   int `json:"internal"`
+}
+
+// AnalyzeImports 对导入路径列表分类，重复和空的路径只计一次，每个分类按路径排序。
+// internal 为内部包的模块路径或前缀，见 Classify
+func AnalyzeImports(imports []string, internal string) *Result {
+	seen := make(map[string]bool)
+	r := &Result{Stdlib: []string{}, ThirdParty: []string{}, Internal: []string{}}
+	for _, pkg := range imports {
+		pkg = strings.TrimSpace(pkg)
+		if pkg == "" || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		switch Classify(pkg, internal) {
+		case Cgo:
+			r.Cgo = true
+		case Stdlib:
+			r.Stdlib = append(r.Stdlib, pkg)
+		case Internal:
+			r.Internal = append(r.Internal, pkg)
+		default:
+			r.ThirdParty = append(r.ThirdParty, pkg)
+		}
+	}
+	sort.Strings(r.Stdlib)
+	sort.Strings(r.ThirdParty)
+	sort.Strings(r.Internal)
+	r.Stats = Stats{
+		Total:      len(r.Stdlib) + len(r.ThirdParty) + len(r.Internal),
+		Stdlib:     len(r.Stdlib),
+		ThirdParty: len(r.ThirdParty),
+		Internal:   len(r.Internal),
+	}
+	return r
+}
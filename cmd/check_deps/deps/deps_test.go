@@ -0,0 +1,56 @@
+package deps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		pkg, internal, want string
+	}{
+		{"fmt", "example.com/app", Stdlib},
+		{"net/http", "example.com/app", Stdlib},
+		{"C", "example.com/app", Cgo},
+		{"github.com/foo/bar", "example.com/app", ThirdParty},
+		{"golang.org/x/sync/errgroup", "example.com/app", ThirdParty},
+		{"example.com/app", "example.com/app", Internal},
+		{"example.com/app/svc/a", "example.com/app", Internal},
+		{"example.com/app/svc/a", "example.com/app/", Internal},
+		// 按路径段匹配，只有前缀相同的其他模块不是内部包
+		{"example.com/application", "example.com/app", ThirdParty},
+		// 没有模块路径时没有内部包
+		{"example.com/app/svc", "", ThirdParty},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.pkg, tt.internal); got != tt.want {
+			t.Errorf("Classify(%q, %q) = %q, want %q", tt.pkg, tt.internal, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeImports(t *testing.T) {
+	got := AnalyzeImports([]string{
+		"os", "fmt", "github.com/foo/bar", "example.com/app/svc", "C", "fmt", "", " os ",
+	}, "example.com/app")
+	want := &Result{
+		Stdlib:     []string{"fmt", "os"},
+		ThirdParty: []string{"github.com/foo/bar"},
+		Internal:   []string{"example.com/app/svc"},
+		Cgo:        true,
+		Stats:      Stats{Total: 4, Stdlib: 2, ThirdParty: 1, Internal: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AnalyzeImports() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAnalyzeImportsEmpty(t *testing.T) {
+	got := AnalyzeImports(nil, "example.com/app")
+	if got.Stdlib == nil || got.ThirdParty == nil || got.Internal == nil {
+		t.Errorf("AnalyzeImports(nil) 的分类应为空列表而不是 nil: %+v", got)
+	}
+	if got.Stats.Total != 0 {
+		t.Errorf("AnalyzeImports(nil).Stats.Total = %d, want 0", got.Stats.Total)
+	}
+}
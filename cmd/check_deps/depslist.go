@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 不允许的外部依赖 (违反 -allow-deps / -deny-deps)
+type disallowedImport struct {
+	Pkg    string
+	Reason string
+	Site   importSite
+}
+
+// 解析逗号分隔的通配符模式列表
+func parsePatternList(list string) []string {
+	var patterns []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return patterns
+}
+
+// 判断包路径本身或其父路径是否匹配任一模式
+func matchAnyPattern(patterns []string, pkg string) (string, bool) {
+	for _, pattern := range patterns {
+		if matchPathPattern(pattern, pkg) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// 按允许列表和禁止列表检查外部依赖 (标准库、内部包和 cgo 不受限制)，禁止列表优先。
+// 设置了允许列表时，不匹配其中任何模式的外部依赖都不允许
+func (da *DependencyAnalyzer) findDisallowedDeps() []disallowedImport {
+	external := make(map[string]bool)
+	for _, cat := range da.categories() {
+		for pkg := range da.categorySet(cat.Key) {
+			if da.isExternalPkg(pkg) {
+				external[pkg] = true
+			}
+		}
+	}
+
+	var found []disallowedImport
+	for _, pkg := range sortedKeys(external) {
+		reason := ""
+		if pattern, ok := matchAnyPattern(da.denyDeps, pkg); ok {
+			reason = "匹配禁止列表中的 " + pattern
+		} else if _, ok := matchAnyPattern(da.allowDeps, pkg); len(da.allowDeps) > 0 && !ok {
+			reason = "不在允许列表中"
+		}
+		if reason == "" {
+			continue
+		}
+		for _, site := range checkedSites(da.sites[pkg]) {
+			found = append(found, disallowedImport{Pkg: pkg, Reason: reason, Site: site})
+		}
+	}
+	return found
+}
+
+// 打印不允许的外部依赖及其导入位置
+func (da *DependencyAnalyzer) printDisallowedDeps(found []disallowedImport) {
+	fmt.Println()
+	if len(found) == 0 {
+		fmt.Println("✅ 外部依赖均符合允许/禁止列表")
+		return
+	}
+	fmt.Printf("❌ 导入了不允许的外部依赖 (%d):\n", len(found))
+	for _, f := range found {
+		fmt.Printf("  %s (%s)  %s:%d\n", f.Pkg, f.Reason, da.relPath(f.Site.File), f.Site.Line)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 按与入口文件的最小导入距离分层打印内部包
+func (da *DependencyAnalyzer) printDepthReport(opts printOptions) {
+	levels := make(map[int][]string)
+	maxLevel := 0
+	for pkg, depth := range da.depths {
+		levels[depth] = append(levels[depth], pkg)
+		if depth > maxLevel {
+			maxLevel = depth
+		}
+	}
+
+	printSectionHeader("内部包层级", opts.quiet)
+	if len(levels) == 0 {
+		fmt.Println("未发现内部包")
+	}
+	for level := 1; level <= maxLevel; level++ {
+		pkgs := levels[level]
+		if len(pkgs) == 0 {
+			continue
+		}
+		sort.Strings(pkgs)
+		fmt.Printf("第 %d 层 (%d):\n", level, len(pkgs))
+		for _, pkg := range pkgs {
+			fmt.Printf("  %s\n", pkg)
+		}
+	}
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// DOT 中各分类节点的填充色，自定义分类使用 dotCustomColor
+var dotColors = map[string]string{
+	"stdlib":      "#b8e186",
+	"extended":    "#a6dba0",
+	"third-party": "#fdb863",
+	"internal":    "#92c5de",
+	"cgo":         "#d9d9d9",
+}
+
+const dotCustomColor = "#d5c1e8"
+
+// 判断包是否已分类且没有被 -match 过滤
+func (da *DependencyAnalyzer) isClassified(pkg string) bool {
+	for _, cat := range append(da.categories(), catCgo) {
+		if da.categorySet(cat.Key)[pkg] {
+			return true
+		}
+	}
+	return false
+}
+
+// 分析过程中记录的导入关系 (导入方 -> 被导入包)，按 -type 只保留指向该分类的边，按包名排序
+func (da *DependencyAnalyzer) graphEdges(filterType string) [][2]string {
+	froms := make([]string, 0, len(da.edges))
+	for from := range da.edges {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	var edges [][2]string
+	for _, from := range froms {
+		for _, to := range sortedKeys(da.edges[from]) {
+			if !da.isClassified(to) || (filterType != "all" && da.category(to) != filterType) {
+				continue
+			}
+			edges = append(edges, [2]string{from, to})
+		}
+	}
+	return edges
+}
+
+// 以 Graphviz DOT 输出依赖图，节点按分类着色 (配合 -d 得到完整的图)
+func (da *DependencyAnalyzer) writeDOT(w io.Writer, entries []string, opts printOptions) error {
+	edges := da.graphEdges(opts.filterType)
+	nodes := make(map[string]bool)
+	for _, e := range edges {
+		nodes[e[0]], nodes[e[1]] = true, true
+	}
+	names := sortedKeys(nodes)
+	sort.SliceStable(names, func(i, j int) bool {
+		return da.categoryRank(da.category(names[i])) < da.categoryRank(da.category(names[j]))
+	})
+
+	fmt.Fprintln(w, "digraph deps {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+	for _, pkg := range names {
+		cat := da.category(pkg)
+		color, ok := dotColors[cat]
+		if !ok {
+			color = dotCustomColor
+		}
+		fmt.Fprintf(w, "  %s [fillcolor=%q, tooltip=%q];\n", strconv.Quote(pkg), color, cat)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(w, "  %s -> %s;\n", strconv.Quote(e[0]), strconv.Quote(e[1]))
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
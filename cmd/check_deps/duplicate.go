@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 同一文件中重复导入的包
+type duplicateImport struct {
+	File  string
+	Path  string
+	Specs []importSpec
+}
+
+// 检查文件中是否重复导入了同一个包 (可能使用了不同的别名)
+func findDuplicateImports(file string, imports []importSpec) []duplicateImport {
+	byPath := make(map[string][]importSpec)
+	var order []string
+	for _, imp := range imports {
+		if _, ok := byPath[imp.Path]; !ok {
+			order = append(order, imp.Path)
+		}
+		byPath[imp.Path] = append(byPath[imp.Path], imp)
+	}
+
+	var dups []duplicateImport
+	for _, p := range order {
+		if specs := byPath[p]; len(specs) > 1 {
+			dups = append(dups, duplicateImport{File: file, Path: p, Specs: specs})
+		}
+	}
+	return dups
+}
+
+// 描述重复导入的位置和别名，如 "第 3 行, 第 7 行 (别名 h)"
+func (d duplicateImport) describe() string {
+	parts := make([]string, 0, len(d.Specs))
+	for _, spec := range d.Specs {
+		if spec.Name != "" {
+			parts = append(parts, fmt.Sprintf("第 %d 行 (别名 %s)", spec.Line, spec.Name))
+		} else {
+			parts = append(parts, fmt.Sprintf("第 %d 行", spec.Line))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// 打印重复导入警告
+func (da *DependencyAnalyzer) printDuplicates() {
+	fmt.Println()
+	fmt.Printf("⚠️  重复导入 (%d):\n", len(da.duplicates))
+	for _, d := range da.duplicates {
+		fmt.Printf("  %s: %s [%s]\n", da.relPath(d.File), d.Path, d.describe())
+	}
+}
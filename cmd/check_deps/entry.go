@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 判断路径是否包含通配符
+func hasGlobMeta(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// 解析 -f 参数得到入口文件的绝对路径列表，支持通配符 (含 **)。
+// 也可以是包目录或包导入路径，此时包内的所有非测试文件 (-all-files 时包含测试文件) 都作为入口，
+// 避免遗漏同一个包中其他文件声明的导入
+func (da *DependencyAnalyzer) resolveEntries(pattern string) ([]string, error) {
+	if !hasGlobMeta(pattern) {
+		absPath, err := filepath.Abs(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("无法获取文件绝对路径: %v", err)
+		}
+		info, err := os.Stat(absPath)
+		if os.IsNotExist(err) {
+			if isImportPathLike(pattern) {
+				return da.resolvePackage(pattern)
+			}
+			return nil, fmt.Errorf("文件不存在: %s", absPath)
+		}
+		if err == nil && info.IsDir() {
+			files := da.buildFiles(goFilesInDir(absPath, da.allFiles))
+			if len(files) == 0 {
+				return nil, fmt.Errorf("目录 %s 中没有 .go 文件", absPath)
+			}
+			return files, nil
+		}
+		return []string{absPath}, nil
+	}
+
+	matches, err := expandPattern(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("无效的通配符 '%s': %v", pattern, err)
+	}
+
+	var entries []string
+	for _, match := range matches {
+		// 跳过目录、非 Go 文件和测试文件
+		if info, err := os.Stat(match); err != nil || info.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(match, ".go") {
+			continue
+		}
+		if !da.allFiles && strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		absPath, err := filepath.Abs(match)
+		if err != nil {
+			return nil, fmt.Errorf("无法获取文件绝对路径: %v", err)
+		}
+		entries = append(entries, absPath)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("通配符 '%s' 未匹配到任何文件", pattern)
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
+
+// 展开通配符，filepath.Glob 不支持 ** 时自行遍历目录匹配
+// 模式统一转换为 / 分隔后逐段匹配，Windows 上的 \ 分隔同样适用
+func expandPattern(pattern string) ([]string, error) {
+	slashed := filepath.ToSlash(pattern)
+	segments := strings.Split(slashed, "/")
+	starAt := -1
+	for i, seg := range segments {
+		if seg == "**" {
+			starAt = i
+			break
+		}
+	}
+	if starAt < 0 {
+		return filepath.Glob(pattern)
+	}
+
+	// ** 之前的部分作为遍历起点，不能再包含通配符
+	root := strings.Join(segments[:starAt], "/")
+	if hasGlobMeta(root) {
+		return nil, fmt.Errorf("** 之前的路径不支持通配符")
+	}
+	switch {
+	case root == "" && strings.HasPrefix(slashed, "/"):
+		root = "/"
+	case root == "":
+		root = "."
+	case root == filepath.VolumeName(root):
+		// 只有盘符 (如 C:) 时从盘符根目录开始，而不是该盘的当前目录
+		root += "/"
+	}
+	for _, seg := range segments[starAt:] {
+		if _, err := path.Match(seg, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	var matches []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(root), p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		if matchSegments(segments[starAt:], strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// 逐段匹配路径，** 可匹配零个或多个目录
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// 判断不存在的 -f 参数是否像包导入路径 (如 example.com/app/svc)，而不是写错的文件或相对路径
+func isImportPathLike(p string) bool {
+	if filepath.IsAbs(p) || strings.HasPrefix(p, ".") || strings.HasSuffix(p, ".go") || strings.Contains(p, `\`) {
+		return false
+	}
+	return true
+}
+
+// 将包导入路径解析为包内的 .go 文件 (-all-files 时包含测试文件)
+func (da *DependencyAnalyzer) resolvePackage(importPath string) ([]string, error) {
+	var dir string
+	if _, ok := da.moduleForPkg(importPath); ok {
+		// 本地模块中的包直接按模块路径映射到目录
+		dir = da.pkgDir(importPath)
+	} else {
+		// 其他包交给 go list 解析
+		out, err := exec.Command("go", "list", "-f", "{{.Dir}}", importPath).Output()
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+				err = fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return nil, fmt.Errorf("本地不存在文件或目录 %s，按包导入路径解析也失败: %v", importPath, err)
+		}
+		dir = strings.TrimSpace(string(out))
+	}
+
+	files := da.buildFiles(goFilesInDir(dir, da.allFiles))
+	if len(files) == 0 {
+		return nil, fmt.Errorf("包 %s 的目录 %s 中没有 .go 文件", importPath, dir)
+	}
+	return files, nil
+}
+
+// 读取文件的 package 声明
+func (da *DependencyAnalyzer) packageName(file string) (string, error) {
+	f, err := da.openSource(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	node, err := parser.ParseFile(token.NewFileSet(), file, f, parser.PackageClauseOnly)
+	if err != nil {
+		return "", err
+	}
+	return node.Name.Name, nil
+}
+
+// 汇总入口文件声明的包名 (去重排序)，无法解析的文件忽略
+func (da *DependencyAnalyzer) entryPackages(entries []string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range entries {
+		name, err := da.packageName(entry)
+		if err != nil || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 逐行打印解析过的文件 (相对项目根目录)，verbose 时在标准错误中列出跳过的生成文件
+func (da *DependencyAnalyzer) printParsedFiles(verbose bool) {
+	for _, file := range da.parsedFiles {
+		fmt.Println(da.relPath(file))
+	}
+	if verbose {
+		for _, file := range da.skippedGenerated {
+			fmt.Fprintf(os.Stderr, "跳过生成文件: %s\n", da.relPath(file))
+		}
+	}
+}
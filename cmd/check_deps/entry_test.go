@@ -0,0 +1,126 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"svc/**/main.go", "svc/main.go", true},
+		{"svc/**/main.go", "svc/a/main.go", true},
+		{"svc/**/main.go", "svc/a/b/main.go", true},
+		{"svc/**/main.go", "other/a/main.go", false},
+		{"svc/*/main.go", "svc/a/b/main.go", false},
+		{"**", "a/b/c.go", true},
+		{"**/*_test.go", "a/b_test.go", true},
+		{"**/*_test.go", "a/b.go", false},
+	}
+	for _, tt := range tests {
+		if got := matchSegments(strings.Split(tt.pattern, "/"), strings.Split(tt.name, "/")); got != tt.want {
+			t.Errorf("matchSegments(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+// 通配符和包目录的解析对 / 和系统路径分隔符 (Windows 上为 \) 给出相同的结果
+func TestPathSeparatorStyles(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"svc/a/main.go":   "package main\n",
+		"svc/b/c/main.go": "package main\n",
+		"svc/b/c/c.go":    "package main\n",
+	})
+	sep := string(filepath.Separator)
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"svc/**/main.go", []string{"svc/a/main.go", "svc/b/c/main.go"}},
+		{"svc/*/main.go", []string{"svc/a/main.go"}},
+		{"svc/b/*/main.go", []string{"svc/b/c/main.go"}},
+	}
+	for _, tt := range tests {
+		var want []string
+		for _, w := range tt.want {
+			want = append(want, filepath.Join(root, filepath.FromSlash(w)))
+		}
+		for _, style := range []string{"/", sep} {
+			p := filepath.Join(root, strings.ReplaceAll(tt.pattern, "/", style))
+			got, err := expandPattern(p)
+			if err != nil {
+				t.Fatalf("expandPattern(%q): %v", p, err)
+			}
+			for i := range got {
+				got[i] = filepath.Clean(got[i])
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expandPattern(%q) = %v, want %v", p, got, want)
+			}
+		}
+	}
+
+	da := NewDependencyAnalyzer(root)
+	dirs := map[string]string{
+		"example.com/m":         root,
+		"example.com/m/svc/a":   filepath.Join(root, "svc", "a"),
+		"example.com/m/svc/b/c": filepath.Join(root, "svc", "b", "c"),
+	}
+	for pkg, dir := range dirs {
+		if got := da.pkgDir(pkg); got != dir {
+			t.Errorf("pkgDir(%q) = %q, want %q", pkg, got, dir)
+		}
+	}
+}
+
+// 通配符匹配到的非 Go 文件和测试文件不作为入口
+func TestResolveEntriesSkipsNonGoFiles(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"svc/a/main.go":      "package main\n",
+		"svc/a/main_test.go": "package main\n",
+		"svc/a/README.md":    "# a\n",
+		"svc/a/asm_amd64.s":  "\n",
+	})
+	da := NewDependencyAnalyzer(root)
+	got, err := da.resolveEntries(filepath.Join(root, "svc", "a", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "svc", "a", "main.go")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveEntries() = %v, want %v", got, want)
+	}
+}
+
+// -internal-prefix 与模块路径不同时，前缀下的包映射到项目根目录下的对应目录
+func TestPkgDirInternalPrefix(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main.go":    "package main\n\nimport _ \"corp.io/app/svc\"\n",
+		"svc/svc.go": "package svc\n\nimport _ \"strings\"\n",
+	})
+	da := NewDependencyAnalyzer(root)
+	da.internalPrefix = "corp.io/app"
+
+	dirs := map[string]string{
+		"corp.io/app":           root,
+		"corp.io/app/svc":       filepath.Join(root, "svc"),
+		"example.com/m/svc":     filepath.Join(root, "svc"),
+		"example.com/m/svc/sub": filepath.Join(root, "svc", "sub"),
+	}
+	for pkg, dir := range dirs {
+		if got := da.pkgDir(pkg); got != dir {
+			t.Errorf("pkgDir(%q) = %q, want %q", pkg, got, dir)
+		}
+	}
+
+	if err := da.analyzeDependencies([]string{filepath.Join(root, "main.go")}, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(da.unresolved) > 0 || !da.stdlib["strings"] {
+		t.Errorf("应解析 corp.io/app/svc 并找到其导入的 strings，无法解析: %v", da.unresolved)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 在导入关系上做广度优先搜索，返回从任一起点到目标包的最短导入链 (不可达时返回 nil)
+func (da *DependencyAnalyzer) importChain(starts []string, target string) []string {
+	prev := make(map[string]string)
+	seen := make(map[string]bool)
+	var queue []string
+	for _, pkg := range starts {
+		if !seen[pkg] {
+			seen[pkg] = true
+			queue = append(queue, pkg)
+		}
+	}
+
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if pkg == target {
+			chain := []string{pkg}
+			for p, ok := prev[pkg]; ok; p, ok = prev[p] {
+				chain = append([]string{p}, chain...)
+			}
+			return chain
+		}
+		// 按包名遍历，保证等长路径中的结果稳定
+		for _, to := range sortedKeys(da.edges[pkg]) {
+			if !seen[to] {
+				seen[to] = true
+				prev[to] = pkg
+				queue = append(queue, to)
+			}
+		}
+	}
+	return nil
+}
+
+// 入口文件所在的包，按包名排序去重
+func (da *DependencyAnalyzer) entryPkgs(entries []string) []string {
+	set := make(map[string]bool)
+	for _, entry := range entries {
+		set[da.pkgOfFile(entry)] = true
+	}
+	return sortedKeys(set)
+}
+
+// 打印从入口到指定包的最短导入链，每一步附带导入位置
+func (da *DependencyAnalyzer) printExplain(entries []string, target string, opts printOptions) {
+	printSectionHeader("导入链: "+target, opts.quiet)
+	chain := da.importChain(da.entryPkgs(entries), target)
+	if chain == nil {
+		fmt.Printf("入口文件不会导入 %s (检查包路径是否正确或是否被 -exclude 排除)\n", target)
+		printSectionFooter(opts.quiet)
+		return
+	}
+	fmt.Println(chain[0])
+	for i := 1; i < len(chain); i++ {
+		if site, ok := da.findSite(chain[i-1], chain[i]); ok {
+			fmt.Printf("%*s└─ %s  (%s:%d)\n", (i-1)*3, "", chain[i], da.relPath(site.File), site.Line)
+		} else {
+			fmt.Printf("%*s└─ %s\n", (i-1)*3, "", chain[i])
+		}
+	}
+	printSectionFooter(opts.quiet)
+}
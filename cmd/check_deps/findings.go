@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// 检查规则
+type rule struct {
+	ID          string
+	Description string
+	Level       string // SARIF 级别: error | warning，为空时视为 error
+}
+
+var (
+	ruleImportCycle     = rule{ID: "import-cycle", Description: "内部包之间存在导入循环"}
+	ruleMissingRequire  = rule{ID: "missing-require", Description: "导入的第三方库未在 go.mod 中 require"}
+	ruleUnresolved      = rule{ID: "unresolved-internal", Description: "导入的内部包目录不存在或没有源文件"}
+	ruleDuplicate       = rule{ID: "duplicate-import", Description: "同一文件中重复导入了同一个包", Level: "warning"}
+	ruleLayer           = rule{ID: "layer-violation", Description: "内部包之间的导入违反分层规则"}
+	ruleCommandImport   = rule{ID: "command-import", Description: "库包导入了 package main 或 cmd/ 目录下的包"}
+	ruleForbidden       = rule{ID: "forbidden-stdlib", Description: "导入了 -forbid-stdlib 禁止的标准库"}
+	ruleDisallowed      = rule{ID: "disallowed-dependency", Description: "导入了 -allow-deps / -deny-deps 不允许的外部依赖"}
+	ruleDanglingReplace = rule{ID: "dangling-replace", Description: "go.mod 中的 replace 指向不存在或没有 go.mod 的本地目录"}
+	ruleDeprecated      = rule{ID: "deprecated-stdlib", Description: "使用了已弃用或冻结的标准库", Level: "warning"}
+	ruleImportOrder     = rule{ID: "import-order", Description: "导入没有按标准库、第三方库、内部包分组或组内未排序"}
+	ruleTestImport      = rule{ID: "test-import", Description: "生产代码导入了测试辅助包"}
+	ruleVisibility      = rule{ID: "internal-visibility", Description: "从 internal 目录的父目录之外导入了其中的包"}
+)
+
+// 所有检查规则
+var allRules = []rule{ruleImportCycle, ruleMissingRequire, ruleUnresolved, ruleDuplicate, ruleLayer, ruleCommandImport, ruleForbidden, ruleDisallowed, ruleDanglingReplace, ruleDeprecated, ruleImportOrder, ruleTestImport, ruleVisibility}
+
+// 一条检查结果，定位到具体的导入行
+type finding struct {
+	Rule    rule
+	Message string
+	File    string
+	Line    int
+}
+
+// 查找导入方包在指定文件中导入目标包的位置
+func (da *DependencyAnalyzer) findSite(from, to string) (importSite, bool) {
+	for _, sites := range [][]importSite{da.sites[to], da.extSites[to]} {
+		for _, site := range sites {
+			if site.From == from {
+				return site, true
+			}
+		}
+	}
+	return importSite{}, false
+}
+
+// 汇总所有检查结果
+func (da *DependencyAnalyzer) collectFindings() []finding {
+	var findings []finding
+
+	for _, cycle := range da.findCycles() {
+		site, _ := da.findSite(cycle[0], cycle[1])
+		findings = append(findings, finding{
+			Rule:    ruleImportCycle,
+			Message: fmt.Sprintf("导入循环: %s", strings.Join(cycle, " -> ")),
+			File:    site.File,
+			Line:    site.Line,
+		})
+	}
+
+	if da.goMod != nil {
+		for _, pkg := range da.findMissingRequires() {
+			for _, site := range checkedSites(da.sites[pkg]) {
+				findings = append(findings, finding{
+					Rule:    ruleMissingRequire,
+					Message: fmt.Sprintf("%s 所属模块未在 go.mod 中 require", pkg),
+					File:    site.File,
+					Line:    site.Line,
+				})
+			}
+		}
+	}
+
+	for _, pkg := range sortedKeys(da.unresolved) {
+		for _, site := range checkedSites(da.sites[pkg]) {
+			findings = append(findings, finding{
+				Rule:    ruleUnresolved,
+				Message: fmt.Sprintf("无法解析内部包 %s", pkg),
+				File:    site.File,
+				Line:    site.Line,
+			})
+		}
+	}
+
+	for _, d := range da.duplicates {
+		findings = append(findings, finding{
+			Rule:    ruleDuplicate,
+			Message: fmt.Sprintf("重复导入 %s: %s", d.Path, d.describe()),
+			File:    d.File,
+			Line:    d.Specs[len(d.Specs)-1].Line,
+		})
+	}
+
+	for _, c := range da.findCommandImports() {
+		findings = append(findings, finding{
+			Rule:    ruleCommandImport,
+			Message: fmt.Sprintf("%s 导入了命令包 %s", c.From, c.To),
+			File:    c.Site.File,
+			Line:    c.Site.Line,
+		})
+	}
+
+	for _, f := range da.findForbiddenStdlib() {
+		findings = append(findings, finding{
+			Rule:    ruleForbidden,
+			Message: fmt.Sprintf("禁止导入标准库 %s", f.Pkg),
+			File:    f.Site.File,
+			Line:    f.Site.Line,
+		})
+	}
+
+	for _, d := range da.findDisallowedDeps() {
+		findings = append(findings, finding{
+			Rule:    ruleDisallowed,
+			Message: fmt.Sprintf("不允许导入 %s: %s", d.Pkg, d.Reason),
+			File:    d.Site.File,
+			Line:    d.Site.Line,
+		})
+	}
+
+	for _, d := range da.findDanglingReplaces() {
+		findings = append(findings, finding{
+			Rule:    ruleDanglingReplace,
+			Message: fmt.Sprintf("replace %s => %s: %s", d.Replace.Old, d.Replace.New, d.Reason),
+			File:    filepath.Join(da.projectPath, "go.mod"),
+		})
+	}
+
+	for _, pkg := range da.findDeprecated() {
+		for _, site := range checkedSites(da.sites[pkg]) {
+			findings = append(findings, finding{
+				Rule:    ruleDeprecated,
+				Message: fmt.Sprintf("%s: %s", pkg, deprecatedStdlib[pkg]),
+				File:    site.File,
+				Line:    site.Line,
+			})
+		}
+	}
+
+	for _, t := range da.findTestImports() {
+		findings = append(findings, finding{
+			Rule:    ruleTestImport,
+			Message: fmt.Sprintf("生产代码导入了测试辅助包 %s", t.Pkg),
+			File:    t.Site.File,
+			Line:    t.Site.Line,
+		})
+	}
+
+	if da.visibility {
+		for _, v := range da.checkInternalVisibility() {
+			findings = append(findings, finding{
+				Rule:    ruleVisibility,
+				Message: fmt.Sprintf("%s 不能导入 %s", v.From, v.To),
+				File:    v.Site.File,
+				Line:    v.Site.Line,
+			})
+		}
+	}
+
+	if da.importOrder {
+		for _, v := range da.checkImportOrder() {
+			findings = append(findings, finding{
+				Rule:    ruleImportOrder,
+				Message: v.Message,
+				File:    v.File,
+				Line:    v.Line,
+			})
+		}
+	}
+
+	if da.layers != nil {
+		for _, v := range da.checkLayers(da.layers) {
+			findings = append(findings, finding{
+				Rule:    ruleLayer,
+				Message: fmt.Sprintf("%s 层的 %s 不允许导入 %s 层的 %s", v.FromLayer, v.From, v.ToLayer, v.To),
+				File:    v.Site.File,
+				Line:    v.Site.Line,
+			})
+		}
+	}
+
+	return findings
+}
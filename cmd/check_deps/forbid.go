@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 导入了禁用的标准库
+type forbiddenImport struct {
+	Pkg  string
+	Rule string // 匹配的禁用项
+	Site importSite
+}
+
+// 解析 -forbid-stdlib 列表，只接受标准库路径 (第一段不含点号)
+func parseForbidStdlib(list string) ([]string, error) {
+	var pkgs []string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if strings.Contains(strings.Split(item, "/")[0], ".") {
+			return nil, fmt.Errorf("-forbid-stdlib 只能指定标准库，'%s' 不是标准库", item)
+		}
+		pkgs = append(pkgs, item)
+	}
+	return pkgs, nil
+}
+
+// 查找导入了禁用标准库的位置，禁用项同时覆盖其子包 (如 net 包括 net/http)
+func (da *DependencyAnalyzer) findForbiddenStdlib() []forbiddenImport {
+	var found []forbiddenImport
+	for _, pkg := range sortedKeys(da.stdlib) {
+		for _, rule := range da.forbidStdlib {
+			if pkg != rule && !strings.HasPrefix(pkg, rule+"/") {
+				continue
+			}
+			for _, site := range checkedSites(da.sites[pkg]) {
+				found = append(found, forbiddenImport{Pkg: pkg, Rule: rule, Site: site})
+			}
+			break
+		}
+	}
+	return found
+}
+
+// 打印禁用标准库的导入位置
+func (da *DependencyAnalyzer) printForbiddenStdlib(found []forbiddenImport) {
+	fmt.Println()
+	if len(found) == 0 {
+		fmt.Println("✅ 未导入禁用的标准库")
+		return
+	}
+	fmt.Printf("❌ 导入了禁用的标准库 (%d):\n", len(found))
+	for _, f := range found {
+		name := f.Pkg
+		if f.Pkg != f.Rule {
+			name += " (禁用 " + f.Rule + ")"
+		}
+		fmt.Printf("  %s  %s:%d\n", name, da.relPath(f.Site.File), f.Site.Line)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// gopkg.in 风格的版本后缀，如 yaml.v3
+var gopkgVersionRe = regexp.MustCompile(`\.v[0-9]+$`)
+
+// 过于常见、不能说明是同一项目的仓库名，不参与 fork 检测
+var genericRepoNames = map[string]bool{
+	"go": true, "sdk": true, "api": true, "client": true, "lib": true, "core": true, "common": true,
+	"utils": true, "util": true, "tools": true, "errors": true, "log": true, "logger": true,
+	"config": true, "proto": true, "protocol": true, "types": true, "server": true,
+}
+
+// 疑似 fork 的一组模块
+type forkCandidate struct {
+	Name    string   // 相同的仓库名
+	Modules []string // 不同托管路径下的模块
+}
+
+// 模块路径中的仓库名: 去掉主版本后缀和 gopkg.in 的版本后缀，转为小写
+func repoName(module string) string {
+	parts := strings.Split(module, "/")
+	if len(parts) > 1 && majorVersionRe.MatchString(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.ToLower(gopkgVersionRe.ReplaceAllString(parts[len(parts)-1], ""))
+}
+
+// 启发式地查找疑似 fork 的模块: 同时导入了仓库名相同、托管路径不同的第三方模块。
+// 为减少误报，只考虑 host/owner/repo 形式的模块，忽略常见的通用仓库名和过短的仓库名，
+// 同一模块的不同主版本视为同一个
+func (da *DependencyAnalyzer) findForkCandidates() []forkCandidate {
+	byName := make(map[string]map[string]bool)
+	for _, m := range da.usedModules("name") {
+		base := m.Path
+		if parts := strings.Split(base, "/"); len(parts) > 3 && majorVersionRe.MatchString(parts[len(parts)-1]) {
+			base = strings.Join(parts[:len(parts)-1], "/")
+		}
+		if strings.Count(base, "/") != 2 {
+			continue
+		}
+		name := repoName(base)
+		if len(name) < 4 || genericRepoNames[name] {
+			continue
+		}
+		if byName[name] == nil {
+			byName[name] = make(map[string]bool)
+		}
+		byName[name][base] = true
+	}
+
+	var candidates []forkCandidate
+	for name, modules := range byName {
+		if len(modules) > 1 {
+			candidates = append(candidates, forkCandidate{Name: name, Modules: sortedKeys(modules)})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+	return candidates
+}
+
+// 打印疑似 fork 的模块
+func printForkCandidates(candidates []forkCandidate) {
+	fmt.Println()
+	fmt.Printf("⚠️  疑似同时使用了原模块和 fork (%d 组，按仓库名启发式匹配，请人工确认):\n", len(candidates))
+	for _, c := range candidates {
+		fmt.Printf("  %s: %s\n", c.Name, strings.Join(c.Modules, ", "))
+	}
+}
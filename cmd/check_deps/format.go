@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 将分析结果以某种格式写入 w，entries 为入口文件
+type formatWriter func(da *DependencyAnalyzer, w io.Writer, entries []string, opts printOptions) error
+
+// -format 支持的输出格式
+type outputFormat struct {
+	Name   string
+	Ext    string       // 写入文件 (如 -split-output) 时使用的扩展名
+	Deep   bool         // 需要内部包之间的导入关系，隐含 -d
+	Binary bool         // 二进制格式，不能直接输出到终端
+	Write  formatWriter // 为 nil 表示默认的文本报告
+}
+
+var outputFormats = []outputFormat{
+	{Name: "text", Ext: ".txt"},
+	{Name: "json", Ext: ".json", Write: (*DependencyAnalyzer).writeJSON},
+	{Name: "dot", Ext: ".dot", Write: (*DependencyAnalyzer).writeDOT},
+	{Name: "mermaid", Ext: ".mmd", Deep: true, Write: (*DependencyAnalyzer).writeMermaid},
+	{Name: "html", Ext: ".html", Write: (*DependencyAnalyzer).writeHTML},
+	{Name: "csv", Ext: ".csv", Write: (*DependencyAnalyzer).writeCSV},
+	{Name: "sarif", Ext: ".sarif", Write: func(da *DependencyAnalyzer, w io.Writer, entries []string, opts printOptions) error {
+		return da.writeSarifTo(w)
+	}},
+	{Name: "cyclonedx", Ext: ".cdx.json", Write: (*DependencyAnalyzer).writeCycloneDX},
+	{Name: "spdx", Ext: ".spdx.json", Write: (*DependencyAnalyzer).writeSPDX},
+	{Name: "markdown", Ext: ".md", Write: (*DependencyAnalyzer).writeMarkdown},
+	{Name: "graphml", Ext: ".graphml", Write: (*DependencyAnalyzer).writeGraphML},
+	{Name: "plantuml", Ext: ".puml", Deep: true, Write: (*DependencyAnalyzer).writePlantUML},
+	{Name: "d2", Ext: ".d2", Deep: true, Write: (*DependencyAnalyzer).writeD2},
+	{Name: "junit", Ext: ".xml", Write: (*DependencyAnalyzer).writeJUnit},
+	{Name: "ndjson", Ext: ".ndjson", Write: (*DependencyAnalyzer).writeNDJSON},
+	{Name: "xlsx", Ext: ".xlsx", Binary: true, Write: (*DependencyAnalyzer).writeXLSX},
+}
+
+// 按名称查找输出格式
+func lookupFormat(name string) (outputFormat, bool) {
+	for _, f := range outputFormats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return outputFormat{}, false
+}
+
+// 解析 -format 的值，可以是逗号分隔的多个格式 (如 json,dot)，多个格式时不能包含
+// text 和 ndjson (两者都直接输出到标准输出)
+func parseFormats(value string) ([]outputFormat, error) {
+	var formats []outputFormat
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		f, ok := lookupFormat(name)
+		if !ok {
+			return nil, fmt.Errorf("不支持的输出格式 '%s'", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			formats = append(formats, f)
+		}
+	}
+	if len(formats) > 1 {
+		for _, f := range formats {
+			if f.Write == nil || f.Name == "ndjson" {
+				return nil, fmt.Errorf("%s 格式不能与其他格式同时输出", f.Name)
+			}
+		}
+	}
+	return formats, nil
+}
+
+// 多个格式时每个格式的输出文件: 以 -o 的值为前缀加上格式的扩展名，
+// -o 为目录 (已存在或以路径分隔符结尾) 时写入目录下的 deps.<扩展名>
+func formatFileName(base string, f outputFormat) string {
+	if strings.HasSuffix(base, "/") || strings.HasSuffix(base, string(filepath.Separator)) {
+		return filepath.Join(base, "deps"+f.Ext)
+	}
+	if info, err := os.Stat(base); err == nil && info.IsDir() {
+		return filepath.Join(base, "deps"+f.Ext)
+	}
+	return base + f.Ext
+}
+
+// 在一次分析后按多个格式分别写入文件，返回写入的文件
+func (da *DependencyAnalyzer) writeFormatFiles(base string, formats []outputFormat, entries []string, opts printOptions) ([]string, error) {
+	var files []string
+	for _, f := range formats {
+		file := formatFileName(base, f)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return nil, err
+		}
+		out, err := os.Create(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.Write(da, out, entries, opts); err != nil {
+			out.Close()
+			return nil, fmt.Errorf("生成 %s 输出失败: %v", f.Name, err)
+		}
+		if err := out.Close(); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// 所有输出格式的名称，用于帮助和错误信息
+func formatNames() string {
+	names := make([]string, len(outputFormats))
+	for i, f := range outputFormats {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// 以 JSON 输出完整的分类结果 (各分类的包列表和统计)，与 -baseline 基线文件的结构相同
+func (da *DependencyAnalyzer) writeJSON(w io.Writer, entries []string, opts printOptions) error {
+	data, err := marshalJSON(da.buildReport(opts.sortBy))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
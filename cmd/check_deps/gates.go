@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// 决定退出码的检查及其参数
+type gateOptions struct {
+	strict         bool       // -strict: 无法解析的内部包、命令包导入和无效的 replace 也视为失败
+	checkMissing   bool       // -check-missing
+	limits         thresholds // -max-third-party 等数量阈值
+	baselineFile   string
+	baselineUpdate bool
+	quiet          bool
+}
+
+// 运行所有决定退出码的检查，返回是否有检查失败。
+// show 为 true 时 (文本报告) 打印每项检查的结果；结构化输出时只计算退出码，不向标准输出写入其他内容
+func (da *DependencyAnalyzer) runGates(g gateOptions, show bool) (bool, error) {
+	failed := false
+
+	// 报告无法解析的内部包，只通过忽略标记引入的不影响退出码
+	if len(da.unresolved) > 0 {
+		if show {
+			da.printUnresolved()
+		}
+		for pkg := range da.unresolved {
+			if g.strict && !da.onlyIgnored(pkg) {
+				failed = true
+			}
+		}
+	}
+
+	// 检查指向无效本地目录的 replace
+	if dangling := da.findDanglingReplaces(); len(dangling) > 0 {
+		if show {
+			printDanglingReplaces(dangling)
+		}
+		if g.strict {
+			failed = true
+		}
+	}
+
+	// 检查库包是否导入了命令包
+	if found := da.findCommandImports(); len(found) > 0 {
+		if show {
+			da.printCommandImports(found)
+		}
+		if g.strict {
+			failed = true
+		}
+	}
+
+	// 检查分层规则
+	if da.layers != nil {
+		violations := da.checkLayers(da.layers)
+		if show {
+			da.printLayerViolations(violations)
+		}
+		if len(violations) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查禁用的标准库
+	if len(da.forbidStdlib) > 0 {
+		found := da.findForbiddenStdlib()
+		if show {
+			da.printForbiddenStdlib(found)
+		}
+		if len(found) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查外部依赖的允许/禁止列表
+	if len(da.allowDeps) > 0 || len(da.denyDeps) > 0 {
+		found := da.findDisallowedDeps()
+		if show {
+			da.printDisallowedDeps(found)
+		}
+		if len(found) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查 internal 目录的可见性
+	if da.visibility {
+		violations := da.checkInternalVisibility()
+		if show {
+			da.printVisibilityViolations(violations)
+		}
+		if len(violations) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查生产代码对测试辅助包的导入
+	if len(da.testHelpers) > 0 {
+		found := da.findTestImports()
+		if show {
+			da.printTestImports(found)
+		}
+		if len(found) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查导入的分组和排序
+	if da.importOrder {
+		violations := da.checkImportOrder()
+		if show {
+			da.printImportOrder(violations)
+		}
+		if len(violations) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查 go.mod 中缺失的 require
+	if g.checkMissing {
+		if da.goMod == nil {
+			return false, fmt.Errorf("未找到 go.mod: %s", filepath.Join(da.projectPath, "go.mod"))
+		}
+		missing := da.findMissingRequires()
+		if show {
+			printMissingRequires(missing)
+		}
+		if len(missing) > 0 {
+			failed = true
+		}
+	}
+
+	// 检查依赖数量阈值
+	if g.limits.maxThirdParty > 0 || g.limits.maxTotal > 0 || g.limits.maxThirdPartyPct > 0 {
+		breaches := da.checkThresholds(g.limits)
+		if show {
+			printThresholdBreaches(breaches)
+		}
+		if len(breaches) > 0 {
+			failed = true
+		}
+	}
+
+	// 与基线对比或更新基线
+	if g.baselineFile != "" {
+		current := da.buildReport("name")
+		if g.baselineUpdate {
+			if err := writeReport(g.baselineFile, current); err != nil {
+				return false, fmt.Errorf("写入基线文件失败: %v", err)
+			}
+			if show && !g.quiet {
+				fmt.Printf("\n基线已更新: %s\n", g.baselineFile)
+			}
+		} else {
+			baseline, err := loadReport(g.baselineFile)
+			if err != nil {
+				return false, fmt.Errorf("读取基线文件失败: %v", err)
+			}
+			diffs := diffReports(baseline, current)
+			if show {
+				printBaselineDiff(g.baselineFile, diffs)
+			}
+			if len(diffs) > 0 {
+				failed = true
+			}
+		}
+	}
+
+	return failed, nil
+}
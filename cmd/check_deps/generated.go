@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// 识别生成文件时扫描的最大行数
+const generatedScanLines = 20
+
+// Go 约定的生成文件标记，见 https://go.dev/s/generatedcode
+var generatedRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// 判断文件开头是否带有 "// Code generated ... DO NOT EDIT." 标记
+func (da *DependencyAnalyzer) isGeneratedFile(file string) bool {
+	f, err := da.openSource(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < generatedScanLines && scanner.Scan(); i++ {
+		// CRLF 换行的文件 (如 Windows 上检出的 protoc 输出) 行尾带有 \r
+		if generatedRe.MatchString(strings.TrimRight(scanner.Text(), "\r")) {
+			return true
+		}
+	}
+	return false
+}
+
+// 打印跳过的生成文件
+func (da *DependencyAnalyzer) printSkippedGenerated() {
+	fmt.Printf("\n已跳过 %d 个生成的文件:\n", len(da.skippedGenerated))
+	for _, file := range da.skippedGenerated {
+		fmt.Printf("  %s\n", da.relPath(file))
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestIsGeneratedFile(t *testing.T) {
+	files := map[string]string{
+		"gen.go":        "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage p\n",
+		"gen_crlf.go":   "// Code generated by protoc-gen-go. DO NOT EDIT.\r\n\r\npackage p\r\n",
+		"later.go":      "// Copyright 2024\n\n// Code generated by stringer; DO NOT EDIT.\n\npackage p\n",
+		"plain.go":      "package p\n",
+		"plain_crlf.go": "package p\r\n",
+		// 标记必须独占一行并以 "DO NOT EDIT." 结尾
+		"inline.go": "package p // Code generated by x. DO NOT EDIT.\n",
+		"suffix.go": "// Code generated by x. DO NOT EDIT. (manual)\n\npackage p\n",
+	}
+	want := map[string]bool{
+		"gen.go":      true,
+		"gen_crlf.go": true,
+		"later.go":    true,
+	}
+	root := writeModule(t, files)
+	da := NewDependencyAnalyzer(root)
+	for name := range files {
+		if name == "go.mod" {
+			continue
+		}
+		if got := da.isGeneratedFile(filepath.Join(root, name)); got != want[name] {
+			t.Errorf("isGeneratedFile(%s) = %v, want %v", name, got, want[name])
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// 转义工作流命令中的消息，属性值 (如 file=) 还需要转义 : 和 ,
+func ghaEscape(s string, property bool) string {
+	s = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+	if property {
+		s = strings.NewReplacer(":", "%3A", ",", "%2C").Replace(s)
+	}
+	return s
+}
+
+// GitHub Actions 集成: 为每条检查结果输出 ::error / ::warning 工作流命令 (在 Actions 界面和 PR 的导入行上显示)，
+// 并将 Markdown 摘要追加到 $GITHUB_STEP_SUMMARY。工作流命令始终写入终端的标准输出，不受 -o 影响
+func (da *DependencyAnalyzer) writeGitHubActions(entries []string, opts printOptions) error {
+	findings := da.collectFindings()
+	root := da.sarifRoot()
+	for _, f := range findings {
+		level := "error"
+		if f.Rule.Level == "warning" {
+			level = "warning"
+		}
+		props := []string{"title=" + ghaEscape(f.Rule.ID, true)}
+		if f.File != "" {
+			props = append(props, "file="+ghaEscape(sarifURI(root, f.File), true))
+			if f.Line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", f.Line))
+			}
+		}
+		fmt.Fprintf(console, "::%s %s::%s\n", level, strings.Join(props, ","), ghaEscape(f.Message, false))
+	}
+
+	summaryFile := os.Getenv("GITHUB_STEP_SUMMARY")
+	if summaryFile == "" {
+		fmt.Fprintln(os.Stderr, "警告: 未设置 GITHUB_STEP_SUMMARY (不在 GitHub Actions 中运行?)，跳过步骤摘要")
+		return nil
+	}
+	// 同一步骤中的多次写入会拼接在一起，因此以追加方式打开
+	f, err := os.OpenFile(summaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := da.writeMarkdown(f, entries, opts); err != nil {
+		f.Close()
+		return err
+	}
+	writeFindingsMarkdown(f, findings, root)
+	return f.Close()
+}
+
+// 在 Markdown 摘要中追加检查结果表格
+func writeFindingsMarkdown(w io.Writer, findings []finding, root string) {
+	fmt.Fprintf(w, "\n## 检查结果 (%d)\n\n", len(findings))
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "未发现问题 ✅")
+		return
+	}
+	fmt.Fprintln(w, "| 级别 | 规则 | 位置 | 说明 |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- |")
+	for _, f := range findings {
+		level := "error"
+		if f.Rule.Level == "warning" {
+			level = "warning"
+		}
+		loc := ""
+		if f.File != "" {
+			loc = sarifURI(root, f.File)
+			if f.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", loc, f.Line)
+			}
+			loc = "`" + mdCell(loc) + "`"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", level, f.Rule.ID, loc, mdCell(f.Message))
+	}
+}
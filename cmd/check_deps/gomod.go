@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// go.mod 中的 require 项
+type moduleRequire struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// go.mod 中的 replace 项，New 为本地路径时 NewVersion 为空
+type moduleReplace struct {
+	Old, OldVersion string
+	New, NewVersion string
+}
+
+// 替换目标是否是本地目录 (以 ./、../ 开头或绝对路径)
+func (r moduleReplace) isLocal() bool {
+	return strings.HasPrefix(r.New, "./") || strings.HasPrefix(r.New, "../") || filepath.IsAbs(r.New) ||
+		r.New == "." || r.New == ".."
+}
+
+// go.mod 解析结果
+type goModFile struct {
+	Module   string
+	Requires []moduleRequire
+	Replaces []moduleReplace
+}
+
+// 解析 go.mod 内容，只关心 module、require 和 replace 指令
+func parseGoMod(data []byte) *goModFile {
+	mf := &goModFile{}
+	block := "" // 当前所在的 require ( ... ) 或 replace ( ... ) 块
+	for _, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		comment := ""
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			comment = strings.TrimSpace(line[idx+2:])
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+
+		if block != "" {
+			switch {
+			case line == ")":
+				block = ""
+			case block == "require":
+				mf.addRequire(line, comment)
+			case block == "replace":
+				mf.addReplace(line)
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "module "):
+			mf.Module = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		case line == "require (" || line == "require(":
+			block = "require"
+		case line == "replace (" || line == "replace(":
+			block = "replace"
+		case strings.HasSuffix(line, "("):
+			// 其他块 (exclude、retract 等) 整体跳过
+			block = "skip"
+		case strings.HasPrefix(line, "require "):
+			mf.addRequire(strings.TrimPrefix(line, "require "), comment)
+		case strings.HasPrefix(line, "replace "):
+			mf.addReplace(strings.TrimPrefix(line, "replace "))
+		}
+	}
+	return mf
+}
+
+// 解析单行 replace，格式为 "<模块路径> [版本] => <替换路径> [版本]"
+func (mf *goModFile) addReplace(line string) {
+	left, right, ok := strings.Cut(line, "=>")
+	if !ok {
+		return
+	}
+	oldFields, newFields := strings.Fields(left), strings.Fields(right)
+	if len(oldFields) == 0 || len(newFields) == 0 {
+		return
+	}
+	r := moduleReplace{Old: strings.Trim(oldFields[0], `"`), New: strings.Trim(newFields[0], `"`)}
+	if len(oldFields) > 1 {
+		r.OldVersion = oldFields[1]
+	}
+	if len(newFields) > 1 {
+		r.NewVersion = newFields[1]
+	}
+	mf.Replaces = append(mf.Replaces, r)
+}
+
+// 解析单行 require，格式为 "<模块路径> <版本>"
+func (mf *goModFile) addRequire(line, comment string) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return
+	}
+	mf.Requires = append(mf.Requires, moduleRequire{
+		Path:     strings.Trim(fields[0], `"`),
+		Version:  fields[1],
+		Indirect: comment == "indirect" || strings.HasPrefix(comment, "indirect;"),
+	})
+}
+
+// 查找包所属的 require 模块（最长前缀匹配）
+func (mf *goModFile) moduleOf(pkg string) (moduleRequire, bool) {
+	var best moduleRequire
+	found := false
+	for _, req := range mf.Requires {
+		if pkg != req.Path && !strings.HasPrefix(pkg, req.Path+"/") {
+			continue
+		}
+		if !found || len(req.Path) > len(best.Path) {
+			best = req
+			found = true
+		}
+	}
+	return best, found
+}
+
+// 查找未在 go.mod 中 require 的外部依赖
+func (da *DependencyAnalyzer) findMissingRequires() []string {
+	var missing []string
+	for _, pkg := range da.externalPackages() {
+		if da.onlyIgnored(pkg) {
+			continue
+		}
+		if _, ok := da.goMod.moduleOf(pkg); !ok {
+			missing = append(missing, pkg)
+		}
+	}
+	return missing
+}
+
+// 打印缺失的 require
+func printMissingRequires(missing []string) {
+	fmt.Println()
+	if len(missing) == 0 {
+		fmt.Println("✅ 所有第三方库均已在 go.mod 中声明")
+		return
+	}
+	fmt.Printf("❌ go.mod 中缺少 require 的第三方库 (%d):\n", len(missing))
+	for _, pkg := range missing {
+		fmt.Printf("  %s\n", pkg)
+	}
+	fmt.Println("提示: 请运行 go mod tidy 同步 go.mod")
+}
+
+// 查找标记为 // indirect 且没有任何包出现在分析结果中的 require，可作为 go mod tidy 的清理候选
+func (da *DependencyAnalyzer) findRemovableRequires() []moduleRequire {
+	used := make(map[string]bool)
+	for _, pkg := range da.externalPackages() {
+		if req, ok := da.goMod.moduleOf(pkg); ok {
+			used[req.Path] = true
+		}
+	}
+	var removable []moduleRequire
+	for _, req := range da.goMod.Requires {
+		if req.Indirect && !used[req.Path] {
+			removable = append(removable, req)
+		}
+	}
+	return removable
+}
+
+// 打印可移除的 indirect require
+func printRemovableRequires(removable []moduleRequire) {
+	fmt.Println()
+	if len(removable) == 0 {
+		fmt.Println("✅ 没有可移除的 indirect 依赖")
+		return
+	}
+	fmt.Printf("🧹 可能可以移除的 indirect 依赖 (%d):\n", len(removable))
+	for _, req := range removable {
+		fmt.Printf("  %s %s\n", req.Path, req.Version)
+	}
+	fmt.Println("提示: 只对照了本次分析到的导入 (建议配合 -d 和 -all-files)，请运行 go mod tidy 确认")
+}
+
+// 本地目录不存在或没有 go.mod 的 replace
+type danglingReplace struct {
+	Replace moduleReplace
+	Dir     string // 解析后的目录
+	Reason  string
+	Used    bool // 分析结果中有包来自被替换的模块
+}
+
+// 检查主模块 go.mod 中指向本地目录的 replace，相对路径相对于 go.mod 所在目录
+func (da *DependencyAnalyzer) findDanglingReplaces() []danglingReplace {
+	if da.goMod == nil {
+		return nil
+	}
+	var dangling []danglingReplace
+	for _, r := range da.goMod.Replaces {
+		if !r.isLocal() {
+			continue
+		}
+		dir := filepath.FromSlash(r.New)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(da.projectPath, dir)
+		}
+		reason := ""
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			reason = "目录不存在"
+		} else if !fileExists(filepath.Join(dir, "go.mod")) {
+			reason = "目录中没有 go.mod"
+		}
+		if reason == "" {
+			continue
+		}
+		d := danglingReplace{Replace: r, Dir: dir, Reason: reason}
+		for _, pkg := range da.externalPackages() {
+			if pkg == r.Old || strings.HasPrefix(pkg, r.Old+"/") {
+				d.Used = true
+				break
+			}
+		}
+		dangling = append(dangling, d)
+	}
+	return dangling
+}
+
+// 打印失效的 replace
+func printDanglingReplaces(dangling []danglingReplace) {
+	fmt.Println()
+	fmt.Printf("⚠️  指向无效本地目录的 replace (%d):\n", len(dangling))
+	for _, d := range dangling {
+		note := ""
+		if d.Used {
+			note = "，本次分析中有包导入了该模块，构建会失败"
+		}
+		fmt.Printf("  %s => %s (%s%s)\n", d.Replace.Old, d.Replace.New, d.Reason, note)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 读取项目根目录下的 go.sum，返回模块 (path@version) 源码的 h1: 哈希，没有 go.sum 时返回空
+func (da *DependencyAnalyzer) readGoSum() map[string]string {
+	sums := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(da.projectPath, "go.sum"))
+	if err != nil {
+		return sums
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		// 跳过 go.mod 文件的哈希 (版本以 /go.mod 结尾)
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+	return sums
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// 查找内部包之间的导入循环，每个强连通分量返回一条具体的循环路径 (首尾相同)
+func (da *DependencyAnalyzer) findCycles() [][]string {
+	// 只保留内部包之间的边
+	graph := make(map[string][]string)
+	for from, tos := range da.edges {
+		if !da.internal[from] {
+			continue
+		}
+		for to := range tos {
+			if da.internal[to] && !da.ignoredEdge(from, to) {
+				graph[from] = append(graph[from], to)
+			}
+		}
+		sort.Strings(graph[from])
+	}
+
+	var cycles [][]string
+	for _, scc := range stronglyConnected(graph) {
+		if len(scc) == 1 && !contains(graph[scc[0]], scc[0]) {
+			continue
+		}
+		members := make(map[string]bool, len(scc))
+		for _, pkg := range scc {
+			members[pkg] = true
+		}
+		cycles = append(cycles, shortestCycle(graph, scc[0], members))
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// Tarjan 算法求强连通分量，分量内按包名排序
+func stronglyConnected(graph map[string][]string) [][]string {
+	nodes := make([]string, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var result [][]string
+
+	var visit func(v string)
+	visit = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range graph[v] {
+			if _, ok := indices[w]; !ok {
+				visit(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var scc []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sort.Strings(scc)
+			result = append(result, scc)
+		}
+	}
+
+	for _, node := range nodes {
+		if _, ok := indices[node]; !ok {
+			visit(node)
+		}
+	}
+	return result
+}
+
+// 在强连通分量内广度优先查找从 start 出发回到 start 的最短路径
+func shortestCycle(graph map[string][]string, start string, members map[string]bool) []string {
+	prev := make(map[string]string)
+	queue := []string{start}
+	seen := map[string]bool{start: true}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[node] {
+			if !members[next] {
+				continue
+			}
+			if next == start {
+				path := []string{start}
+				for n := node; n != start; n = prev[n] {
+					path = append(path, n)
+				}
+				// 反转中间部分后补上终点
+				for i, j := 1, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return append(path, start)
+			}
+			if !seen[next] {
+				seen[next] = true
+				prev[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return []string{start, start}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// 深度分析到的内部包 (含入口文件所在的包，不含无法解析的包) 以及它们之间的导入关系
+func (da *DependencyAnalyzer) internalGraph(entries []string) (nodes []string, graph map[string]map[string]bool) {
+	set := make(map[string]bool)
+	for _, pkg := range da.entryPkgs(entries) {
+		set[pkg] = true
+	}
+	for pkg := range da.depths {
+		if !da.unresolved[pkg] {
+			set[pkg] = true
+		}
+	}
+	graph = make(map[string]map[string]bool, len(set))
+	for pkg := range set {
+		graph[pkg] = make(map[string]bool)
+		for to := range da.edges[pkg] {
+			if set[to] && to != pkg {
+				graph[pkg][to] = true
+			}
+		}
+	}
+	return sortedKeys(set), graph
+}
+
+// 以 CSV 输出内部包的邻接矩阵: 首行和首列为按包名排序的包路径，行导入列时单元格为 1，否则为 0
+func (da *DependencyAnalyzer) writeAdjacencyCSV(entries []string) error {
+	nodes, graph := da.internalGraph(entries)
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(append([]string{""}, nodes...)); err != nil {
+		return err
+	}
+	for _, from := range nodes {
+		row := make([]string, 0, len(nodes)+1)
+		row = append(row, from)
+		for _, to := range nodes {
+			if graph[from][to] {
+				row = append(row, "1")
+			} else {
+				row = append(row, "0")
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// 不导入任何其他内部包的内部包 (只依赖标准库和第三方库)
+func (da *DependencyAnalyzer) leafPackages(entries []string) []string {
+	nodes, graph := da.internalGraph(entries)
+	var leaves []string
+	for _, pkg := range nodes {
+		if len(graph[pkg]) == 0 {
+			leaves = append(leaves, pkg)
+		}
+	}
+	return leaves
+}
+
+// 没有被任何其他内部包导入的内部包
+func (da *DependencyAnalyzer) rootPackages(entries []string) []string {
+	nodes, graph := da.internalGraph(entries)
+	imported := make(map[string]bool)
+	for _, tos := range graph {
+		for to := range tos {
+			imported[to] = true
+		}
+	}
+	var roots []string
+	for _, pkg := range nodes {
+		if !imported[pkg] {
+			roots = append(roots, pkg)
+		}
+	}
+	return roots
+}
+
+// 打印内部包列表
+func printPackageList(title string, pkgs []string, opts printOptions) {
+	printSectionHeader(fmt.Sprintf("%s (%d)", title, len(pkgs)), opts.quiet)
+	for _, pkg := range pkgs {
+		fmt.Printf("  %s\n", pkg)
+	}
+	printSectionFooter(opts.quiet)
+}
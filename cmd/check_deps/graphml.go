@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"go/build"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// GraphML 文档，只包含用到的元素
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// 包源码所在的目录: 标准库在 GOROOT 中，外部包在模块缓存 (或 replace 的本地目录) 中，内部包在项目中
+func (da *DependencyAnalyzer) sourceDir(pkg string) (string, bool) {
+	switch {
+	case pkg == "C":
+		return "", false
+	case da.isStdLib(pkg):
+		return filepath.Join(build.Default.GOROOT, "src", filepath.FromSlash(pkg)), true
+	case da.isExternalPkg(pkg):
+		return da.externalPkgDir(pkg)
+	}
+	return da.pkgDir(pkg), true
+}
+
+// 统计包目录 (不含子目录) 中非测试 .go 文件的行数，目录不存在时返回 false
+func packageLines(dir string) (int, bool) {
+	files := goFilesInDir(dir, false)
+	if len(files) == 0 {
+		return 0, false
+	}
+	lines := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return 0, false
+		}
+		lines += bytes.Count(data, []byte("\n"))
+	}
+	return lines, true
+}
+
+// 以 GraphML 输出依赖图: 节点带分类、所属模块和代码行数，边带导入位置的数量，便于在 Gephi 或 yEd 中浏览大图
+func (da *DependencyAnalyzer) writeGraphML(w io.Writer, entries []string, opts printOptions) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "category", For: "node", Name: "category", Type: "string"},
+			{ID: "module", For: "node", Name: "module", Type: "string"},
+			{ID: "lines", For: "node", Name: "lines", Type: "int"},
+			{ID: "direct", For: "node", Name: "direct", Type: "boolean"},
+			{ID: "sites", For: "edge", Name: "sites", Type: "int"},
+		},
+		Graph: graphMLGraph{ID: "deps", EdgeDefault: "directed"},
+	}
+
+	edges := da.graphEdges(opts.filterType)
+	nodes := make(map[string]bool)
+	for _, e := range edges {
+		nodes[e[0]], nodes[e[1]] = true, true
+	}
+	for _, pkg := range sortedKeys(nodes) {
+		node := graphMLNode{ID: pkg, Data: []graphMLData{{Key: "category", Value: da.category(pkg)}}}
+		if da.isExternalPkg(pkg) {
+			mod, _ := da.moduleOfPkg(pkg)
+			node.Data = append(node.Data, graphMLData{Key: "module", Value: mod})
+		} else if m, ok := da.moduleForPkg(pkg); ok {
+			node.Data = append(node.Data, graphMLData{Key: "module", Value: m.Path})
+		}
+		if dir, ok := da.sourceDir(pkg); ok {
+			if lines, ok := packageLines(dir); ok {
+				node.Data = append(node.Data, graphMLData{Key: "lines", Value: strconv.Itoa(lines)})
+			}
+		}
+		node.Data = append(node.Data, graphMLData{Key: "direct", Value: strconv.FormatBool(da.direct[pkg])})
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for _, e := range edges {
+		sites := 0
+		for _, list := range [][]importSite{da.sites[e[1]], da.extSites[e[1]]} {
+			for _, site := range list {
+				if site.From == e[0] {
+					sites++
+				}
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: e[0],
+			Target: e[1],
+			Data:   []graphMLData{{Key: "sites", Value: strconv.Itoa(sites)}},
+		})
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header+strings.TrimSpace(string(data))+"\n")
+	return err
+}
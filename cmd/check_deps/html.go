@@ -0,0 +1,143 @@
+package main
+
+import (
+	"html/template"
+	"io"
+)
+
+// 输出单个 HTML 文件的报告: 可折叠的导入树和可搜索的分类表格，数据和脚本都内嵌在页面中
+func (da *DependencyAnalyzer) writeHTML(w io.Writer, entries []string, opts printOptions) error {
+	return htmlTemplate.Execute(w, da.buildView(entries, opts))
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="zh">
+<head>
+<meta charset="utf-8">
+<title>依赖分析报告</title>
+<style>
+body { font: 14px/1.5 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.25em; margin-top: 1.5em; border-bottom: 1px solid #d0d7de; }
+code, .tree { font-family: SFMono-Regular, Menlo, Consolas, monospace; font-size: 13px; }
+.stats span { display: inline-block; margin-right: 1.5em; }
+#search { width: 100%; max-width: 480px; padding: 6px 8px; font-size: 14px; }
+table { border-collapse: collapse; margin-top: .5em; min-width: 480px; }
+th, td { text-align: left; padding: 3px 12px 3px 0; border-bottom: 1px solid #eaeef2; }
+th { cursor: pointer; user-select: none; }
+.direct { color: #1a7f37; }
+.tree details { margin-left: 1.2em; }
+.tree summary { cursor: pointer; }
+.tree .leaf { margin-left: 2.4em; }
+.cycle { color: #cf222e; }
+.cat-stdlib { color: #1a7f37; } .cat-extended { color: #0a7ea4; } .cat-third-party { color: #9a6700; }
+.cat-internal { color: #0969da; } .cat-cgo { color: #6e7781; }
+</style>
+</head>
+<body>
+<h1>依赖分析报告</h1>
+<p>入口: {{range $i, $e := .Entries}}{{if $i}}, {{end}}<code>{{$e}}</code>{{end}}{{if not .Deep}} (浅层分析，配合 -d 可得到完整的导入树){{end}}</p>
+<p class="stats">
+<span>总计: <b>{{.Report.Stats.Total}}</b></span>
+<span>标准库: <b>{{.Report.Stats.Stdlib}}</b></span>
+{{if .Report.Stats.Extended}}<span>扩展库: <b>{{.Report.Stats.Extended}}</b></span>{{end}}
+<span>第三方库: <b>{{.Report.Stats.ThirdParty}}</b></span>
+<span>内部包: <b>{{.Report.Stats.Internal}}</b></span>
+{{range $k, $v := .Report.Stats.Custom}}<span>{{$k}}: <b>{{$v}}</b></span>{{end}}
+</p>
+
+<h2>导入树</h2>
+<div id="tree" class="tree"></div>
+
+<h2>包列表</h2>
+<input id="search" type="search" placeholder="搜索包路径或模块…">
+<div id="tables"></div>
+
+<script>
+const data = {{.}};
+
+// 导入树: 展开时才生成子节点，祖先链中已出现的包标记为循环
+function node(pkg, ancestors) {
+  const cat = categoryOf(pkg);
+  const children = data.Edges[pkg] || [];
+  const label = '<span class="cat-' + cat + '">' + esc(pkg) + '</span>';
+  if (ancestors.has(pkg)) {
+    const div = document.createElement('div');
+    div.className = 'leaf cycle';
+    div.innerHTML = label + ' ↻ 循环';
+    return div;
+  }
+  if (children.length === 0) {
+    const div = document.createElement('div');
+    div.className = 'leaf';
+    div.innerHTML = label;
+    return div;
+  }
+  const details = document.createElement('details');
+  details.innerHTML = '<summary>' + label + ' (' + children.length + ')</summary>';
+  details.addEventListener('toggle', function () {
+    if (!details.open || details.dataset.loaded) return;
+    details.dataset.loaded = '1';
+    const next = new Set(ancestors).add(pkg);
+    children.forEach(function (child) { details.appendChild(node(child, next)); });
+  });
+  return details;
+}
+
+const categories = {};
+data.Categories && data.Categories.forEach(function (c) {
+  c.Packages.forEach(function (p) { categories[p.Path] = c.Key; });
+});
+function categoryOf(pkg) { return categories[pkg] || 'internal'; }
+function esc(s) { return String(s).replace(/[&<>"]/g, function (c) { return {'&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;'}[c]; }); }
+
+const tree = document.getElementById('tree');
+(data.Roots || []).forEach(function (root) {
+  const n = node(root, new Set());
+  if (n.tagName === 'DETAILS') n.open = true;
+  tree.appendChild(n);
+  n.dispatchEvent(new Event('toggle'));
+});
+
+// 分类表格，点击表头排序
+const tables = document.getElementById('tables');
+(data.Categories || []).forEach(function (c) {
+  const h = document.createElement('h3');
+  h.textContent = c.Title + ' (' + c.Packages.length + ')';
+  const table = document.createElement('table');
+  table.innerHTML = '<thead><tr><th>包</th><th>模块</th><th>导入次数</th><th>直接导入</th></tr></thead>';
+  const body = document.createElement('tbody');
+  c.Packages.forEach(function (p) {
+    const tr = document.createElement('tr');
+    tr.innerHTML = '<td><code>' + esc(p.Path) + '</code></td><td><code>' + esc(p.Module) + '</code></td><td>' +
+      p.Count + '</td><td class="direct">' + (p.Direct ? '✓' : '') + '</td>';
+    body.appendChild(tr);
+  });
+  table.appendChild(body);
+  table.querySelectorAll('th').forEach(function (th, col) {
+    th.addEventListener('click', function () {
+      const rows = Array.from(body.rows);
+      const asc = th.dataset.order !== 'asc';
+      th.dataset.order = asc ? 'asc' : 'desc';
+      rows.sort(function (a, b) {
+        const x = a.cells[col].textContent, y = b.cells[col].textContent;
+        const cmp = col === 2 ? Number(x) - Number(y) : x.localeCompare(y);
+        return asc ? cmp : -cmp;
+      });
+      rows.forEach(function (r) { body.appendChild(r); });
+    });
+  });
+  tables.appendChild(h);
+  tables.appendChild(table);
+});
+
+document.getElementById('search').addEventListener('input', function (e) {
+  const q = e.target.value.trim().toLowerCase();
+  tables.querySelectorAll('tbody tr').forEach(function (tr) {
+    tr.style.display = tr.textContent.toLowerCase().includes(q) ? '' : 'none';
+  });
+});
+</script>
+</body>
+</html>
+`))
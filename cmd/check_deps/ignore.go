@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// 导入行末尾的忽略标记，其后可以写原因，如 // depcheck:ignore 已评审的例外
+const ignoreDirective = "depcheck:ignore"
+
+// 被行内注释显式忽略的导入
+type ignoredImport struct {
+	Pkg    string
+	File   string
+	Line   int
+	Reason string
+}
+
+// 读取导入行末尾注释中的忽略标记，返回标记后的原因
+func importIgnoreReason(imp *ast.ImportSpec) (string, bool) {
+	if imp.Comment == nil {
+		return "", false
+	}
+	for _, c := range imp.Comment.List {
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if rest, ok := strings.CutPrefix(text, ignoreDirective); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
+
+// 去掉带有忽略标记的导入位置，供策略检查使用
+func checkedSites(sites []importSite) []importSite {
+	var checked []importSite
+	for _, site := range sites {
+		if !site.Ignored {
+			checked = append(checked, site)
+		}
+	}
+	return checked
+}
+
+// 判断包是否只通过带有忽略标记的导入引入，这样的包不参与策略检查和数量阈值
+func (da *DependencyAnalyzer) onlyIgnored(pkg string) bool {
+	sites := da.sites[pkg]
+	return len(sites) > 0 && len(checkedSites(sites)) == 0
+}
+
+// 判断导入关系 from -> to 的所有导入位置是否都带有忽略标记
+func (da *DependencyAnalyzer) ignoredEdge(from, to string) bool {
+	found := false
+	for _, site := range da.sites[to] {
+		if site.From != from {
+			continue
+		}
+		if !site.Ignored {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// 集合中参与检查的包数量 (不含只通过忽略标记引入的包)
+func (da *DependencyAnalyzer) checkedCount(set map[string]bool) int {
+	n := 0
+	for pkg := range set {
+		if !da.onlyIgnored(pkg) {
+			n++
+		}
+	}
+	return n
+}
+
+// 打印显式忽略的导入
+func (da *DependencyAnalyzer) printIgnored() {
+	fmt.Println()
+	fmt.Printf("ℹ️  显式忽略的导入 (%d，仍计入分类，但不参与策略检查和数量阈值):\n", len(da.ignored))
+	for _, ig := range da.ignored {
+		line := fmt.Sprintf("  %s  %s:%d", ig.Pkg, da.relPath(ig.File), ig.Line)
+		if ig.Reason != "" {
+			line += " (" + ig.Reason + ")"
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// 导入分组的顺序 (goimports 约定): 标准库、第三方库、内部包
+var importGroupKeys = []string{"stdlib", "third-party", "internal"}
+
+// 不符合导入分组和排序约定的位置
+type importOrderViolation struct {
+	File    string
+	Line    int
+	Message string
+}
+
+// 导入所属的分组序号，扩展库和自定义分类中的外部包归入第三方库，cgo 返回 -1
+func (da *DependencyAnalyzer) importGroup(pkg string) int {
+	switch {
+	case pkg == "C":
+		return -1
+	case da.isStdLib(pkg):
+		return 0
+	case da.isInternalPkg(pkg):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// 分组的标题，使用当前语言和 -label-* 设置的分类标题
+func (da *DependencyAnalyzer) importGroupTitle(group int) string {
+	key := importGroupKeys[group]
+	for _, cat := range da.categories() {
+		if cat.Key == key {
+			return cat.Title
+		}
+	}
+	return key
+}
+
+// 按空行把导入声明拆分为分组，注释视为其后导入的一部分
+func importBlocks(fset *token.FileSet, node *ast.File) [][]*ast.ImportSpec {
+	var blocks [][]*ast.ImportSpec
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		var block []*ast.ImportSpec
+		prevEnd := 0
+		for _, s := range gen.Specs {
+			spec := s.(*ast.ImportSpec)
+			start := spec.Pos()
+			if spec.Doc != nil {
+				start = spec.Doc.Pos()
+			}
+			if len(block) > 0 && fset.Position(start).Line > prevEnd+1 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			block = append(block, spec)
+			prevEnd = fset.Position(spec.End()).Line
+		}
+		if len(block) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// 检查一个文件的导入是否按 标准库、第三方库、内部包 分组，并在分组内按路径排序
+func (da *DependencyAnalyzer) checkFileImportOrder(file string) ([]importOrderViolation, error) {
+	f, err := da.openSource(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, file, f, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []importOrderViolation
+	report := func(spec *ast.ImportSpec, format string, args ...any) {
+		violations = append(violations, importOrderViolation{
+			File:    file,
+			Line:    fset.Position(spec.Pos()).Line,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	lastGroup := 0
+	for _, block := range importBlocks(fset, node) {
+		blockGroup, prevPath := -1, ""
+		for _, spec := range block {
+			// 带有 // depcheck:ignore 标记的导入不参与顺序检查
+			if _, ignored := importIgnoreReason(spec); ignored {
+				continue
+			}
+			pkg := strings.Trim(spec.Path.Value, `"`)
+			if da.normalize {
+				pkg = normalizeImportPath(pkg)
+			}
+			group := da.importGroup(pkg)
+			if group < 0 {
+				continue
+			}
+			switch {
+			case blockGroup < 0:
+				blockGroup = group
+				if group < lastGroup {
+					report(spec, "%s分组应排在%s分组之前", da.importGroupTitle(group), da.importGroupTitle(lastGroup))
+				}
+			case group != blockGroup:
+				report(spec, "%s 属于%s，不应与%s放在同一分组 (分组之间用空行分隔)", pkg, da.importGroupTitle(group), da.importGroupTitle(blockGroup))
+			case pkg < prevPath:
+				report(spec, "%s 应排在 %s 之前", pkg, prevPath)
+			}
+			prevPath = pkg
+		}
+		if blockGroup > lastGroup {
+			lastGroup = blockGroup
+		}
+	}
+	return violations, nil
+}
+
+// 检查所有已分析文件的导入顺序，按文件和行号排序
+func (da *DependencyAnalyzer) checkImportOrder() []importOrderViolation {
+	var violations []importOrderViolation
+	for file := range da.fileImports {
+		found, err := da.checkFileImportOrder(file)
+		if err != nil {
+			continue
+		}
+		violations = append(violations, found...)
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations
+}
+
+// 打印导入顺序检查结果
+func (da *DependencyAnalyzer) printImportOrder(violations []importOrderViolation) {
+	fmt.Println()
+	if len(violations) == 0 {
+		fmt.Printf("✅ %d 个文件的导入都已按%s、%s、%s分组并排序\n", len(da.fileImports),
+			da.importGroupTitle(0), da.importGroupTitle(1), da.importGroupTitle(2))
+		return
+	}
+	files := make(map[string]bool)
+	for _, v := range violations {
+		files[v.File] = true
+	}
+	fmt.Printf("❌ 导入顺序不符合约定 (%d 个文件，%d 处):\n", len(files), len(violations))
+	for _, v := range violations {
+		fmt.Printf("  %s:%d  %s\n", da.relPath(v.File), v.Line, v.Message)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"sort"
+)
+
+// 导入数量的分布
+type importDistribution struct {
+	Mean   float64
+	Median float64
+	Max    int
+	MaxOf  string // 导入最多的文件或包
+}
+
+// 计算导入数量的平均数、中位数和最大值，最大值相同时取名称最小的
+func distribution(counts map[string]int) (importDistribution, bool) {
+	if len(counts) == 0 {
+		return importDistribution{}, false
+	}
+	names := make([]string, 0, len(counts))
+	values := make([]int, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var d importDistribution
+	sum := 0
+	for _, name := range names {
+		n := counts[name]
+		values = append(values, n)
+		sum += n
+		if d.MaxOf == "" || n > d.Max {
+			d.Max, d.MaxOf = n, name
+		}
+	}
+	sort.Ints(values)
+	d.Mean = float64(sum) / float64(len(values))
+	if mid := len(values) / 2; len(values)%2 == 1 {
+		d.Median = float64(values[mid])
+	} else {
+		d.Median = float64(values[mid-1]+values[mid]) / 2
+	}
+	return d, true
+}
+
+// 每个已分析文件的导入数量 (包括被 -exclude 排除的导入)
+func (da *DependencyAnalyzer) fileImportCounts() map[string]int {
+	counts := make(map[string]int, len(da.fileImports))
+	for file, imports := range da.fileImports {
+		counts[da.relPath(file)] = len(imports)
+	}
+	return counts
+}
+
+// 每个已分析的包导入的不同包的数量
+func (da *DependencyAnalyzer) pkgImportCounts() map[string]int {
+	counts := make(map[string]int)
+	for file := range da.fileImports {
+		pkg := da.pkgOfFile(file)
+		counts[pkg] = len(da.edges[pkg])
+	}
+	return counts
+}
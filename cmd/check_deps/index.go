@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// 索引文件格式的版本，格式变化时递增，旧版本的索引会被重建
+const indexVersion = 1
+
+// 项目范围的导入索引: 记录每个文件的修改时间、大小、内容哈希和导入列表，
+// 再次分析时未改动的文件直接使用索引中的导入而不重新解析
+type importIndex struct {
+	Version int                   `json:"version"`
+	Files   map[string]indexEntry `json:"files"` // 键为相对项目根目录的路径 (/ 分隔)
+
+	hits, misses int
+}
+
+// 索引中的一个文件
+type indexEntry struct {
+	ModTime int64        `json:"mtime"` // UnixNano
+	Size    int64        `json:"size"`
+	Hash    string       `json:"sha256"`
+	Imports []importSpec `json:"imports"`
+}
+
+// 读取索引文件，文件不存在、格式版本不同或 rebuild 时返回空索引
+func loadIndex(file string, rebuild bool) (*importIndex, error) {
+	idx := &importIndex{Version: indexVersion, Files: make(map[string]indexEntry)}
+	if rebuild {
+		return idx, nil
+	}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var loaded importIndex
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, fmt.Errorf("解析索引 %s 失败: %v", file, err)
+	}
+	if loaded.Version != indexVersion || loaded.Files == nil {
+		return idx, nil
+	}
+	return &loaded, nil
+}
+
+// 索引中文件的键
+func (da *DependencyAnalyzer) indexKey(file string) string {
+	if rel, err := filepath.Rel(da.projectPath, file); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file)
+}
+
+// 通过索引获取文件的导入: 修改时间和大小都未变时直接使用；否则比较内容哈希
+// (CI 中重新检出的文件修改时间会变)，内容也变化时重新解析并更新索引
+func (da *DependencyAnalyzer) parseIndexed(file string) ([]importSpec, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	key := da.indexKey(file)
+	entry, ok := da.index.Files[key]
+	if ok && entry.ModTime == info.ModTime().UnixNano() && entry.Size == info.Size() {
+		da.index.hits++
+		return entry.Imports, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if ok && entry.Hash == hash {
+		da.index.hits++
+		entry.ModTime, entry.Size = info.ModTime().UnixNano(), info.Size()
+		da.index.Files[key] = entry
+		return entry.Imports, nil
+	}
+
+	da.index.misses++
+	imports, err := parseImports(file, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	da.index.Files[key] = indexEntry{ModTime: info.ModTime().UnixNano(), Size: info.Size(), Hash: hash, Imports: imports}
+	return imports, nil
+}
+
+// 写入索引，去掉已不存在的文件，自动创建父目录
+func (da *DependencyAnalyzer) saveIndex(file string) error {
+	for key := range da.index.Files {
+		if _, err := os.Stat(filepath.Join(da.projectPath, filepath.FromSlash(key))); err != nil {
+			delete(da.index.Files, key)
+		}
+	}
+	data, err := json.Marshal(da.index)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// 以 CSV 输出依赖清单: 每个包一行，列为包路径、分类、所属模块、版本和最早的导入位置
+func (da *DependencyAnalyzer) writeCSV(w io.Writer, entries []string, opts printOptions) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"package", "category", "module", "version", "first_import"}); err != nil {
+		return err
+	}
+	for _, cat := range append(da.categories(), catCgo) {
+		if opts.filterType != "all" && opts.filterType != cat.Key {
+			continue
+		}
+		for _, pkg := range da.sortedSet(da.categorySet(cat.Key), opts.sortBy) {
+			module, version := "", ""
+			if da.isExternalPkg(pkg) {
+				module, version = da.moduleOfPkg(pkg)
+			} else if m, ok := da.moduleForPkg(pkg); ok {
+				module = m.Path
+			}
+			first := ""
+			if site, ok := da.firstSite(pkg); ok {
+				first = fmt.Sprintf("%s:%d", da.relPath(site.File), site.Line)
+			}
+			if err := cw.Write([]string{pkg, cat.Key, module, version, first}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JUnit XML 报告，只包含用到的元素
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// 规则对应的检查是否在本次运行中启用 (需要参数开启的检查未开启时记为跳过)
+func (da *DependencyAnalyzer) ruleEnabled(r rule) bool {
+	switch r.ID {
+	case ruleMissingRequire.ID:
+		return da.goMod != nil
+	case ruleLayer.ID:
+		return da.layers != nil
+	case ruleForbidden.ID:
+		return len(da.forbidStdlib) > 0
+	case ruleDisallowed.ID:
+		return len(da.allowDeps) > 0 || len(da.denyDeps) > 0
+	case ruleImportOrder.ID:
+		return da.importOrder
+	case ruleTestImport.ID:
+		return len(da.testHelpers) > 0
+	case ruleVisibility.ID:
+		return da.visibility
+	}
+	return true
+}
+
+// 将检查结果转换为 JUnit 报告: 每条规则一个测试用例，error 级别的问题记为失败，
+// warning 级别的问题只写入 system-out，未启用的检查记为跳过
+func (da *DependencyAnalyzer) buildJUnit(findings []finding) junitSuites {
+	byRule := make(map[string][]finding)
+	for _, f := range findings {
+		byRule[f.Rule.ID] = append(byRule[f.Rule.ID], f)
+	}
+
+	suite := junitSuite{Name: "check_deps"}
+	for _, r := range allRules {
+		tc := junitCase{ClassName: "check_deps", Name: r.ID + ": " + r.Description}
+		var lines []string
+		for _, f := range byRule[r.ID] {
+			loc := ""
+			if f.File != "" {
+				loc = da.relPath(f.File)
+				if f.Line > 0 {
+					loc += fmt.Sprintf(":%d", f.Line)
+				}
+				loc += ": "
+			}
+			lines = append(lines, loc+f.Message)
+		}
+		switch {
+		case !da.ruleEnabled(r):
+			tc.Skipped = &junitSkipped{Message: "检查未启用"}
+			suite.Skipped++
+		case len(lines) > 0 && r.Level == "warning":
+			tc.SystemOut = strings.Join(lines, "\n")
+		case len(lines) > 0:
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d 个问题", len(lines)),
+				Type:    r.ID,
+				Text:    strings.Join(lines, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+	}
+	return junitSuites{
+		Name:     "check_deps",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []junitSuite{suite},
+	}
+}
+
+// 以 JUnit XML 输出检查结果 (-format junit)，供 Jenkins、GitLab 等在测试报告中展示
+func (da *DependencyAnalyzer) writeJUnit(w io.Writer, entries []string, opts printOptions) error {
+	data, err := xml.MarshalIndent(da.buildJUnit(da.collectFindings()), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header+string(data)+"\n")
+	return err
+}
@@ -0,0 +1,90 @@
+package main
+
+// 主报告 (分类列表和统计信息) 中使用的文字，其他分节仍使用中文
+type messages struct {
+	Results  string // 结果标题
+	Stats    string // 统计标题
+	Cgo      string // cgo 伪包标题
+	More     string // top-n 省略提示，参数为省略数量
+	Indirect string // 间接导入标记
+	Transit  string // 只通过第三方包传递引入的标记
+	Total    string // 总计，参数为包数量
+	CgoNote  string
+	Direct   string // 参数为直接和间接导入数量
+	Count    string // 单个分类统计，参数为分类名和包数量
+	PerFile  string // 每个文件的导入数分布，参数为平均数、中位数、最大值和对应文件
+	PerPkg   string // 每个包的导入数分布
+	NoDoc    string // -synopsis 时没有文档的包
+	Titles   map[string]string
+}
+
+// 支持的输出语言
+var locales = map[string]messages{
+	"zh": {
+		Results:  "依赖分析结果",
+		Stats:    "统计信息",
+		Cgo:      "⚙️  cgo 伪包:",
+		More:     "  ... 还有 %d 个\n",
+		Indirect: " [间接]",
+		Transit:  " [传递]",
+		Total:    "总计: %d 个包\n",
+		CgoNote:  "使用了 cgo (import \"C\"，不计入总计)",
+		Direct:   "直接导入: %d 个包, 间接导入: %d 个包\n",
+		Count:    "%s: %d 个包\n",
+		PerFile:  "每个文件的导入数: 平均 %.1f, 中位数 %.1f, 最多 %d (%s)\n",
+		PerPkg:   "每个包的导入数: 平均 %.1f, 中位数 %.1f, 最多 %d (%s)\n",
+		NoDoc:    "(无文档)",
+		Titles: map[string]string{
+			"stdlib":      "标准库",
+			"extended":    "扩展库",
+			"third-party": "第三方库",
+			"internal":    "内部包",
+		},
+	},
+	"en": {
+		Results:  "Dependency Analysis",
+		Stats:    "Statistics",
+		Cgo:      "⚙️  cgo pseudo-package:",
+		More:     "  ... %d more\n",
+		Indirect: " [indirect]",
+		Transit:  " [transitive]",
+		Total:    "Total: %d packages\n",
+		CgoNote:  "Uses cgo (import \"C\", not counted in total)",
+		Direct:   "Direct imports: %d packages, indirect imports: %d packages\n",
+		Count:    "%s: %d packages\n",
+		PerFile:  "Imports per file: mean %.1f, median %.1f, max %d (%s)\n",
+		PerPkg:   "Imports per package: mean %.1f, median %.1f, max %d (%s)\n",
+		NoDoc:    "(no doc)",
+		Titles: map[string]string{
+			"stdlib":      "Standard library",
+			"extended":    "Extended (golang.org/x)",
+			"third-party": "Third-party",
+			"internal":    "Internal",
+		},
+	},
+}
+
+// 当前使用的文字
+var msg = locales["zh"]
+
+// 切换输出语言并更新内置分类的标题，不支持的语言返回 false
+func setLanguage(lang string) bool {
+	m, ok := locales[lang]
+	if !ok {
+		return false
+	}
+	msg = m
+	for _, cat := range []*categoryInfo{&catStdlib, &catExtended, &catThirdParty, &catInternal} {
+		cat.Title = m.Titles[cat.Key]
+	}
+	return true
+}
+
+// 用 -label-* 覆盖内置分类的标题，空字符串表示不覆盖
+func setCategoryLabels(labels map[string]string) {
+	for _, cat := range []*categoryInfo{&catStdlib, &catExtended, &catThirdParty, &catInternal} {
+		if label := labels[cat.Key]; label != "" {
+			cat.Title = label
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// 分层规则: 每层允许直接导入的其他层
+//
+// 规则文件格式，每行一条，# 开头为注释:
+//
+//	handler -> service
+//	service -> repository, model
+//	repository -> model
+//	model ->
+//
+// 内部包按路径中的目录名归属到层 (匹配多个时取最深的一层)。同层之间的导入总是允许的，
+// 未在规则文件中声明的层不受约束。
+type layerRules map[string]map[string]bool
+
+// 违反分层规则的导入
+type layerViolation struct {
+	From, To           string // 导入方包和被导入包
+	FromLayer, ToLayer string
+	Site               importSite
+}
+
+// 读取分层规则文件
+func loadLayerRules(file string) (layerRules, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	rules := make(layerRules)
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(line, "->")
+		from = strings.TrimSpace(from)
+		if !ok || from == "" {
+			return nil, fmt.Errorf("第 %d 行: 格式应为 \"<层> -> <允许导入的层>, ...\"", i+1)
+		}
+		if rules[from] == nil {
+			rules[from] = make(map[string]bool)
+		}
+		for _, layer := range strings.Split(to, ",") {
+			if layer = strings.TrimSpace(layer); layer != "" {
+				rules[from][layer] = true
+			}
+		}
+	}
+	return rules, nil
+}
+
+// 获取内部包所属的层，不属于任何已声明的层时返回空
+func (rules layerRules) layerOf(relPkg string) string {
+	segments := strings.Split(relPkg, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if _, ok := rules[segments[i]]; ok {
+			return segments[i]
+		}
+	}
+	return ""
+}
+
+// 检查内部包之间的导入是否违反分层规则
+func (da *DependencyAnalyzer) checkLayers(rules layerRules) []layerViolation {
+	var violations []layerViolation
+	for from, tos := range da.edges {
+		if !da.isInternalPkg(from) {
+			continue
+		}
+		fromLayer := rules.layerOf(strings.TrimPrefix(from, da.goModPath))
+		if fromLayer == "" {
+			continue
+		}
+		for to := range tos {
+			if !da.internal[to] || da.ignoredEdge(from, to) {
+				continue
+			}
+			toLayer := rules.layerOf(strings.TrimPrefix(to, da.goModPath))
+			if toLayer == "" || toLayer == fromLayer || rules[fromLayer][toLayer] {
+				continue
+			}
+			site, _ := da.findSite(from, to)
+			violations = append(violations, layerViolation{
+				From: from, To: to, FromLayer: fromLayer, ToLayer: toLayer, Site: site,
+			})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].From != violations[j].From {
+			return violations[i].From < violations[j].From
+		}
+		return violations[i].To < violations[j].To
+	})
+	return violations
+}
+
+// 打印分层规则检查结果
+func (da *DependencyAnalyzer) printLayerViolations(violations []layerViolation) {
+	fmt.Println()
+	if len(violations) == 0 {
+		fmt.Println("✅ 未发现违反分层规则的导入")
+		return
+	}
+	fmt.Printf("❌ 违反分层规则的导入 (%d):\n", len(violations))
+	for _, v := range violations {
+		fmt.Printf("  %s (%s) -> %s (%s)\n", v.From, v.FromLayer, v.To, v.ToLayer)
+		if v.Site.File != "" {
+			fmt.Printf("    %s:%d\n", da.relPath(v.Site.File), v.Site.Line)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 本地的 Go 模块 (项目根模块或嵌套模块)
+type localModule struct {
+	Path string // 模块路径
+	Dir  string // go.mod 所在目录
+	Mod  *goModFile
+}
+
+// 读取目录下的 go.mod
+func readLocalModule(dir string) (localModule, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return localModule{}, false
+	}
+	mod := parseGoMod(data)
+	if mod.Module == "" {
+		return localModule{}, false
+	}
+	return localModule{Path: mod.Module, Dir: dir, Mod: mod}, true
+}
+
+// 从目录向上查找最近的 go.mod
+func nearestModule(dir string) (localModule, bool) {
+	for {
+		if m, ok := readLocalModule(dir); ok {
+			return m, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return localModule{}, false
+		}
+		dir = parent
+	}
+}
+
+// 查找项目目录下的嵌套模块，跳过 skipSourceDir 中的目录
+func findNestedModules(root string) []localModule {
+	var modules []localModule
+	filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || p == root {
+			return nil
+		}
+		if skipSourceDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if m, ok := readLocalModule(p); ok {
+			modules = append(modules, m)
+		}
+		return nil
+	})
+	return modules
+}
+
+// 登记本地模块，已登记的目录忽略
+func (da *DependencyAnalyzer) addLocalModule(m localModule) {
+	for _, existing := range da.localModules {
+		if existing.Dir == m.Dir {
+			return
+		}
+	}
+	if da.normalize {
+		m.Path = normalizeImportPath(m.Path)
+	}
+	da.localModules = append(da.localModules, m)
+}
+
+// 包所属的本地模块 (模块路径最长前缀匹配)
+func (da *DependencyAnalyzer) moduleForPkg(pkg string) (localModule, bool) {
+	var best localModule
+	found := false
+	for _, m := range da.localModules {
+		if pkg != m.Path && !strings.HasPrefix(pkg, m.Path+"/") {
+			continue
+		}
+		if !found || len(m.Path) > len(best.Path) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// 目录所属的本地模块 (模块目录最长前缀匹配)
+func (da *DependencyAnalyzer) moduleForDir(dir string) (localModule, bool) {
+	var best localModule
+	found := false
+	for _, m := range da.localModules {
+		rel, err := filepath.Rel(m.Dir, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if !found || len(m.Dir) > len(best.Dir) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// 按入口文件所在的模块确定主模块: 从入口文件目录向上查找最近的 go.mod 并登记，
+// 所有入口都属于同一个模块时，以该模块作为主模块 (模块路径、go.mod 和项目根目录)
+func (da *DependencyAnalyzer) useEntryModules(entries []string) {
+	var main *localModule
+	same := true
+	for _, entry := range entries {
+		m, ok := nearestModule(filepath.Dir(entry))
+		if !ok {
+			same = false
+			continue
+		}
+		da.addLocalModule(m)
+		if main == nil {
+			main = &m
+		} else if main.Dir != m.Dir {
+			same = false
+		}
+	}
+	if !same || main == nil || main.Dir == da.projectPath {
+		return
+	}
+	da.projectPath = main.Dir
+	da.goModPath = main.Path
+	da.goMod = main.Mod
+	if da.normalize {
+		da.goModPath = normalizeImportPath(da.goModPath)
+	}
+}
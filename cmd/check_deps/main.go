@@ -1,14 +1,22 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"sync"
+
+	"github.com/geekeryy/scripts/cmd/check_deps/deps"
 )
 
 type DependencyAnalyzer struct {
@@ -16,123 +24,497 @@ type DependencyAnalyzer struct {
 	stdlib      map[string]bool
 	thirdParty  map[string]bool
 	internal    map[string]bool
+	extended    map[string]bool            // golang.org/x 扩展库，仅在 -split-xtools 时使用
+	cgo         map[string]bool            // cgo 伪包 "C"
+	custom      map[string]map[string]bool // 自定义分类的包集合: 分类名 -> 包
+	counts      map[string]int             // 每个包被导入的次数
+	direct      map[string]bool            // 入口文件直接导入的包
+	depths      map[string]int             // 内部包与入口文件的最小导入距离
+	edges       map[string]map[string]bool // 导入关系: 导入方包 -> 被导入包
+	sites       map[string][]importSite    // 每个包被导入的位置
+	fileImports map[string][]importSpec    // 每个已分析文件的导入声明
+	pkgNames    map[string]string          // 已分析的包的 package 声明
+	unresolved  map[string]bool            // 目录不存在或没有源文件的内部包
+	followed    map[string]bool            // -deep-third-party 时已进入的第三方包
+	extFiles    map[string]string          // -deep-third-party 时分析的第三方包源码: 文件 -> 所属的包
+	extSites    map[string][]importSite    // 第三方包源码中的导入位置，与 sites 分开，不参与文件级的检查
+	duplicates  []duplicateImport          // 同一文件中重复导入的包
+	ignored     []ignoredImport            // 带有 // depcheck:ignore 标记的导入
+	synopses    map[string]string          // -synopsis 时包文档的第一句，为空表示没有文档
+	net         *netClient                 // 所有网络请求共用的客户端
+	index       *importIndex               // -index 时的导入索引，为 nil 表示每次都解析文件
 	projectPath string
 	goPath      string
 	goModPath   string
+	goMod       *goModFile // 为 nil 表示未找到 go.mod
+	// 本地模块: 主模块、项目内的嵌套模块以及入口文件所在的模块
+	localModules []localModule
+
+	excludes       []string   // 排除的包路径模式
+	internalPrefix string     // 内部包前缀，设置后代替 go.mod 中的模块路径
+	alsoInternal   []string   // 额外视为内部包的路径前缀，在默认判断之外补充
+	splitXTools    bool       // 将 golang.org/x 单独归为扩展库
+	skipGenerated  bool       // 跳过带有生成代码标记的文件
+	layers         layerRules // 分层规则，为 nil 表示不检查
+	forbidStdlib   []string   // 禁止导入的标准库 (含子包)
+	allowDeps      []string   // 允许的外部依赖模式，为空表示不限制
+	denyDeps       []string   // 禁止的外部依赖模式
+	importOrder    bool       // 检查导入的分组和排序
+	testHelpers    []string   // 测试辅助包的路径特征，为空表示不检查
+	visibility     bool       // 检查 internal 目录的可见性规则
+	deepThirdParty bool       // 深度分析时也递归第三方包 (读取模块缓存中的源码)
+	maxDepth       int        // 深度分析的最大层数，0 表示不限制
+	classifyRules  []classifyRule
+	customCats     []categoryInfo // 自定义规则引入的新分类
+	stream         bool           // 分类后立即输出每个新发现的包
+	ndjson         bool           // -format ndjson: 分析过程中逐行输出发现的包和导入关系
+	normalize      bool           // 规范化导入路径 (域名小写、去掉多余的路径分隔)
+	trace          bool           // 在标准错误中输出分析过程
+	showProgress   bool           // 在标准错误 (终端) 中显示分析进度
+	streamMu       sync.Mutex     // 保证流式输出的每一行完整输出，不与其他输出交错
+	allFiles       bool           // 分析所有文件，包括测试文件并忽略构建约束
+	buildCtx       *build.Context // 按构建约束过滤包内的文件，为 nil 表示不过滤
+
+	parsedFiles      []string // 按分析顺序排列的已解析文件
+	stdinFile        string   // -f - 时标准输入对应的虚拟文件路径
+	stdinSrc         []byte   // 从标准输入读取的源码
+	skippedGenerated []string // 已跳过的生成文件
 }
 
 func NewDependencyAnalyzer(projectPath string) *DependencyAnalyzer {
 	goPath := os.Getenv("GOPATH")
 	if goPath == "" {
-		goPath = filepath.Join(os.Getenv("HOME"), "go")
+		// Windows 上没有 HOME，使用 UserHomeDir 读取 USERPROFILE
+		home, _ := os.UserHomeDir()
+		goPath = filepath.Join(home, "go")
 	}
 
-	// 读取 go.mod 获取模块路径
+	// 向上查找最近的 go.mod 获取模块路径和依赖声明，在子目录中运行时以模块根目录作为项目根目录
 	goModPath := ""
-	modData, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
-	if err == nil {
-		lines := strings.Split(string(modData), "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(strings.TrimSpace(line), "module ") {
-				goModPath = strings.TrimSpace(strings.TrimPrefix(line, "module "))
-				break
-			}
-		}
+	var goMod *goModFile
+	var modules []localModule
+	if m, ok := nearestModule(projectPath); ok {
+		projectPath = m.Dir
+		goModPath = m.Path
+		goMod = m.Mod
+		modules = append([]localModule{m}, findNestedModules(m.Dir)...)
 	}
 
+	da := newAnalyzer()
+	da.projectPath = projectPath
+	da.goPath = goPath
+	da.goModPath = goModPath
+	da.goMod = goMod
+	da.localModules = modules
+	return da
+}
+
+// 创建未关联项目的分析器，只初始化各个集合
+func newAnalyzer() *DependencyAnalyzer {
 	return &DependencyAnalyzer{
 		visited:     make(map[string]bool),
 		stdlib:      make(map[string]bool),
 		thirdParty:  make(map[string]bool),
 		internal:    make(map[string]bool),
-		projectPath: projectPath,
-		goPath:      goPath,
-		goModPath:   goModPath,
+		extended:    make(map[string]bool),
+		cgo:         make(map[string]bool),
+		custom:      make(map[string]map[string]bool),
+		counts:      make(map[string]int),
+		direct:      make(map[string]bool),
+		depths:      make(map[string]int),
+		edges:       make(map[string]map[string]bool),
+		sites:       make(map[string][]importSite),
+		fileImports: make(map[string][]importSpec),
+		pkgNames:    make(map[string]string),
+		unresolved:  make(map[string]bool),
+		followed:    make(map[string]bool),
+		extFiles:    make(map[string]string),
+		extSites:    make(map[string][]importSite),
+		net:         newNetClient(defaultNetTimeout, defaultNetRetries, false),
 	}
 }
 
+// 为指定的项目目录创建分析器，沿用当前的分类和过滤配置
+func (da *DependencyAnalyzer) withConfig(projectPath string) *DependencyAnalyzer {
+	var n *DependencyAnalyzer
+	if projectPath == da.projectPath {
+		// 同一个项目沿用已找到的本地模块，不再重复遍历项目目录
+		n = newAnalyzer()
+		n.projectPath = da.projectPath
+		n.goPath = da.goPath
+		n.goModPath = da.goModPath
+		n.goMod = da.goMod
+		n.localModules = append([]localModule(nil), da.localModules...)
+	} else {
+		n = NewDependencyAnalyzer(projectPath)
+	}
+	n.internalPrefix = da.internalPrefix
+	n.alsoInternal = da.alsoInternal
+	n.splitXTools = da.splitXTools
+	n.skipGenerated = da.skipGenerated
+	n.allFiles = da.allFiles
+	n.excludes = da.excludes
+	n.deepThirdParty = da.deepThirdParty
+	n.maxDepth = da.maxDepth
+	n.setClassifyRules(da.classifyRules)
+	if da.normalize {
+		n.enableNormalize()
+	}
+	n.trace = da.trace
+	n.net = da.net
+	return n
+}
+
 // 判断是否是标准库
 func (da *DependencyAnalyzer) isStdLib(pkg string) bool {
-	// 标准库特征：不包含点号或者是以 golang.org/x/ 开头
-	if !strings.Contains(strings.Split(pkg, "/")[0], ".") {
+	return deps.IsStdlib(pkg)
+}
+
+// 判断是否是内部包，前缀按路径段匹配 (与 deps.Classify 一致)，
+// example.com/app 不包括 example.com/application
+func (da *DependencyAnalyzer) isInternalPkg(pkg string) bool {
+	if da.matchAlsoInternal(pkg) {
 		return true
 	}
+	if da.internalPrefix != "" {
+		return deps.HasPathPrefix(pkg, da.internalPrefix)
+	}
+	if _, ok := da.moduleForPkg(pkg); ok {
+		return true
+	}
+	if da.goModPath != "" {
+		return deps.HasPathPrefix(pkg, da.goModPath)
+	}
+	return deps.HasPathPrefix(pkg, "xiaoiron.com/admin")
+}
+
+// 判断包是否匹配 -also-internal 的前缀 (包路径本身或其子包)
+func (da *DependencyAnalyzer) matchAlsoInternal(pkg string) bool {
+	for _, prefix := range da.alsoInternal {
+		if deps.HasPathPrefix(pkg, prefix) {
+			return true
+		}
+	}
 	return false
 }
 
-// 判断是否是内部包
-func (da *DependencyAnalyzer) isInternalPkg(pkg string) bool {
-	if da.goModPath != "" {
-		return strings.HasPrefix(pkg, da.goModPath)
+// 只因 -also-internal 被视为内部包、在本地找不到源码目录的包，深度分析时不进入
+func (da *DependencyAnalyzer) isAlsoInternalOnly(pkg string) bool {
+	if !da.matchAlsoInternal(pkg) {
+		return false
+	}
+	if _, ok := da.moduleForPkg(pkg); ok {
+		return false
 	}
-	return strings.HasPrefix(pkg, "xiaoiron.com/admin")
+	return da.goModPath == "" || !deps.HasPathPrefix(pkg, da.goModPath)
 }
 
-// 解析文件获取导入的包
-func (da *DependencyAnalyzer) parseFile(filePath string) ([]string, error) {
+// 文件中的一条导入声明
+type importSpec struct {
+	Path string `json:"path"`
+	Name string `json:"name,omitempty"` // 导入别名，未设置时为空
+	Line int    `json:"line"`
+	// 行末带有 // depcheck:ignore 标记，照常分类，但不参与策略检查和数量阈值
+	Ignored      bool   `json:"ignored,omitempty"`
+	IgnoreReason string `json:"ignoreReason,omitempty"`
+}
+
+// 包被导入的位置
+type importSite struct {
+	File    string // 导入方文件
+	Line    int
+	From    string // 导入方包
+	Ignored bool   // 带有 // depcheck:ignore 标记，不参与策略检查
+}
+
+// 打开源文件，-f - 时标准输入的虚拟文件返回已读取的内容。
+// 所有需要读取入口文件的地方 (解析导入、包名、生成文件标记、导入顺序) 都应通过这里读取
+func (da *DependencyAnalyzer) openSource(filePath string) (io.ReadCloser, error) {
+	if da.stdinFile != "" && filePath == da.stdinFile {
+		return io.NopCloser(bytes.NewReader(da.stdinSrc)), nil
+	}
+	return os.Open(filePath)
+}
+
+// 解析文件获取导入的包，标准输入的虚拟文件从已读取的内容中解析
+func (da *DependencyAnalyzer) parseFile(filePath string) ([]importSpec, error) {
+	if da.index != nil && filePath != da.stdinFile {
+		return da.parseIndexed(filePath)
+	}
+	f, err := da.openSource(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseImports(filePath, f)
+}
+
+// 从 r 读取源码并解析导入的包，filename 用于错误信息
+func parseImports(filename string, r io.Reader) ([]importSpec, error) {
 	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ImportsOnly)
+	node, err := parser.ParseFile(fset, filename, r, parser.ImportsOnly|parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
 
-	var imports []string
+	var imports []importSpec
 	for _, imp := range node.Imports {
-		// 去除引号
-		path := strings.Trim(imp.Path.Value, `"`)
-		imports = append(imports, path)
+		spec := importSpec{
+			// 去除引号
+			Path: strings.Trim(imp.Path.Value, `"`),
+			Line: fset.Position(imp.Pos()).Line,
+		}
+		if imp.Name != nil {
+			spec.Name = imp.Name.Name
+		}
+		spec.IgnoreReason, spec.Ignored = importIgnoreReason(imp)
+		imports = append(imports, spec)
 	}
 
 	return imports, nil
 }
 
+// 获取文件所在包的导入路径，按所在的本地模块计算，不在项目内时返回所在目录
+func (da *DependencyAnalyzer) pkgOfFile(file string) string {
+	if pkg, ok := da.extFiles[file]; ok {
+		return pkg
+	}
+	dir := filepath.Dir(file)
+	if m, ok := da.moduleForDir(dir); ok {
+		rel, _ := filepath.Rel(m.Dir, dir)
+		if rel == "." {
+			return m.Path
+		}
+		return m.Path + "/" + filepath.ToSlash(rel)
+	}
+	rel, err := filepath.Rel(da.projectPath, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dir
+	}
+	if rel == "." {
+		return da.goModPath
+	}
+	if da.goModPath == "" {
+		return filepath.ToSlash(rel)
+	}
+	return da.goModPath + "/" + filepath.ToSlash(rel)
+}
+
+// 记录导入关系和导入位置
+func (da *DependencyAnalyzer) recordImport(file string, imp importSpec) {
+	from := da.pkgOfFile(file)
+	if da.edges[from] == nil {
+		da.edges[from] = make(map[string]bool)
+	}
+	da.edges[from][imp.Path] = true
+	site := importSite{File: file, Line: imp.Line, From: from, Ignored: imp.Ignored}
+	if da.isThirdPartyFile(file) {
+		da.extSites[imp.Path] = append(da.extSites[imp.Path], site)
+	} else {
+		da.sites[imp.Path] = append(da.sites[imp.Path], site)
+	}
+	if da.ndjson {
+		da.emitNDJSON(ndjsonRecord{Type: "import", From: from, To: imp.Path, File: da.relPath(file), Line: imp.Line})
+	}
+}
+
+// 判断包是否被 -exclude 排除，模式匹配包路径本身或其父路径
+func (da *DependencyAnalyzer) isExcluded(pkg string) bool {
+	for _, pattern := range da.excludes {
+		if matchPathPattern(pattern, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// 判断通配符模式是否匹配包路径本身或其父路径
+func matchPathPattern(pattern, pkg string) bool {
+	for p := pkg; p != "." && p != "/"; p = path.Dir(p) {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// 只保留路径匹配正则表达式的包 (在分类之后应用)
+func (da *DependencyAnalyzer) keepMatching(re *regexp.Regexp) {
+	for _, cat := range append(da.categories(), catCgo) {
+		set := da.categorySet(cat.Key)
+		for pkg := range set {
+			if !re.MatchString(pkg) {
+				delete(set, pkg)
+			}
+		}
+	}
+}
+
 // 分类包
 func (da *DependencyAnalyzer) classifyPackage(pkg string) {
+	da.counts[pkg]++
 	if da.visited[pkg] {
 		return
 	}
 	da.visited[pkg] = true
+	category := da.classify(pkg)
+	da.categorySet(category)[pkg] = true
+	if da.stream {
+		da.streamMu.Lock()
+		fmt.Printf("[%s] %s\n", category, pkg)
+		da.streamMu.Unlock()
+	}
+	if da.ndjson {
+		da.emitNDJSON(ndjsonRecord{Type: "package", Path: pkg, Category: category})
+	}
+}
 
-	if da.isStdLib(pkg) {
-		da.stdlib[pkg] = true
-	} else if da.isInternalPkg(pkg) {
-		da.internal[pkg] = true
-	} else {
-		da.thirdParty[pkg] = true
+// 判断包应归入的分类，自定义规则优先
+func (da *DependencyAnalyzer) classify(pkg string) string {
+	if pkg == "C" {
+		// import "C" 是 cgo 的伪包，既不是标准库也不是第三方库
+		return "cgo"
+	}
+	if category, ok := da.matchClassifyRule(pkg); ok {
+		return category
+	}
+	switch {
+	case da.isStdLib(pkg):
+		return "stdlib"
+	case da.isInternalPkg(pkg):
+		return "internal"
+	case da.splitXTools && strings.HasPrefix(pkg, "golang.org/x/"):
+		return "extended"
+	default:
+		return "third-party"
 	}
 }
 
-// 递归分析依赖
-func (da *DependencyAnalyzer) analyzeDependencies(startFile string, deep bool) error {
-	imports, err := da.parseFile(startFile)
-	if err != nil {
-		return fmt.Errorf("解析文件 %s 失败: %v", startFile, err)
-	}
-
-
-	for _, pkg := range imports {
-		da.classifyPackage(pkg)
-
-		// 如果是深度分析且是内部包，继续递归
-		if deep && da.isInternalPkg(pkg) {
-			pkgPath := strings.TrimPrefix(pkg, da.goModPath+"/")
-			fullPath := filepath.Join(da.projectPath, pkgPath)
-
-			// 检查是否是目录
-			if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
-				// 查找目录中的所有 .go 文件
-				files, err := filepath.Glob(filepath.Join(fullPath, "*.go"))
-				if err == nil {
-					for _, file := range files {
-						// 跳过测试文件
-						if strings.HasSuffix(file, "_test.go") {
-							continue
-						}
-						if !da.visited[file] {
-							da.visited[file] = true
-							da.analyzeDependencies(file, deep)
-						}
-					}
+// 在标准错误中输出一条分析过程日志，按层级缩进
+func (da *DependencyAnalyzer) tracef(level int, format string, args ...any) {
+	if da.trace {
+		fmt.Fprintf(os.Stderr, strings.Repeat("  ", level)+format+"\n", args...)
+	}
+}
+
+// 待分析的文件，level 为与入口文件的距离 (入口文件为 0)
+type fileTask struct {
+	file  string
+	level int
+}
+
+// 广度优先分析依赖，深度分析时逐层进入内部包
+func (da *DependencyAnalyzer) analyzeDependencies(entries []string, deep bool) error {
+	prog := newProgress(da.showProgress && !da.trace && !da.stream && !da.ndjson)
+	defer prog.done()
+
+	queue := make([]fileTask, 0, len(entries))
+	for _, entry := range entries {
+		da.visited[realPath(entry)] = true
+		queue = append(queue, fileTask{file: entry})
+	}
+
+	for len(queue) > 0 {
+		task := queue[0]
+		queue = queue[1:]
+
+		if da.skipGenerated && da.isGeneratedFile(task.file) {
+			da.tracef(task.level, "跳过生成文件 %s", da.relPath(task.file))
+			da.skippedGenerated = append(da.skippedGenerated, task.file)
+			continue
+		}
+
+		da.parsedFiles = append(da.parsedFiles, task.file)
+		imports, err := da.parseFile(task.file)
+		if err != nil {
+			da.tracef(task.level, "解析 %s 失败: %v", da.relPath(task.file), err)
+			if task.level == 0 {
+				return fmt.Errorf("解析文件 %s 失败: %v", task.file, err)
+			}
+			continue
+		}
+		da.tracef(task.level, "解析 %s (%d 个导入)", da.relPath(task.file), len(imports))
+		prog.update(len(da.parsedFiles), len(da.counts))
+		// 第三方包的源码只用于发现传递依赖，不计入按文件的统计和检查 (导入顺序、禁用的标准库等)
+		if !da.isThirdPartyFile(task.file) {
+			da.fileImports[task.file] = imports
+		}
+		// 记录包名 (外部测试包 xxx_test 不代表包本身)
+		if pkg := da.pkgOfFile(task.file); da.pkgNames[pkg] == "" {
+			if name, err := da.packageName(task.file); err == nil && !strings.HasSuffix(name, "_test") {
+				da.pkgNames[pkg] = name
+			}
+		}
+		if !da.isThirdPartyFile(task.file) {
+			da.duplicates = append(da.duplicates, findDuplicateImports(task.file, imports)...)
+		}
+
+		for _, imp := range imports {
+			if da.normalize {
+				imp.Path = normalizeImportPath(imp.Path)
+			}
+			pkg := imp.Path
+			if da.isExcluded(pkg) {
+				da.tracef(task.level, "  %s: 已排除", pkg)
+				continue
+			}
+			// 外部测试包 (package xxx_test) 导入被测包本身，不计入依赖
+			if pkg == da.pkgOfFile(task.file) {
+				da.tracef(task.level, "  %s: 所在包自身，不计入", pkg)
+				continue
+			}
+			// 带有忽略标记的导入照常分类和递归，只在策略检查中跳过
+			if imp.Ignored {
+				da.tracef(task.level, "  %s: 不参与检查 (%s)", pkg, ignoreDirective)
+				da.ignored = append(da.ignored, ignoredImport{Pkg: pkg, File: task.file, Line: imp.Line, Reason: imp.IgnoreReason})
+			}
+			da.classifyPackage(pkg)
+			da.tracef(task.level, "  %s: %s", pkg, da.category(pkg))
+			da.recordImport(task.file, imp)
+			if task.level == 0 {
+				da.direct[pkg] = true
+			}
+
+			// 是否继续分析下一层按最终分类判断，自定义规则可以将包归入或移出内部包
+			internal := da.classify(pkg) == "internal"
+
+			// -deep-third-party 时第三方包也继续分析下一层
+			if deep && da.deepThirdParty && !internal && da.isExternalPkg(pkg) {
+				queue = append(queue, da.followThirdParty(pkg, task.level)...)
+				continue
+			}
+
+			// 如果是深度分析且是内部包，继续分析下一层
+			if !deep || !internal || da.isAlsoInternalOnly(pkg) {
+				continue
+			}
+			depth, seen := da.depths[pkg]
+			if !seen || task.level+1 < depth {
+				da.depths[pkg] = task.level + 1
+			}
+			if seen {
+				continue
+			}
+			if da.maxDepth > 0 && task.level+1 > da.maxDepth {
+				da.tracef(task.level, "  %s: 超过 -max-depth %d，不再深入", pkg, da.maxDepth)
+				continue
+			}
+			files := da.packageFiles(pkg)
+			switch {
+			case len(files) == 0 && !da.isInternalPkg(pkg):
+				// 只按自定义规则归为内部包，本地没有对应的源码
+				da.tracef(task.level, "  %s: 本地没有源码，不再深入", pkg)
+			case len(files) == 0:
+				da.tracef(task.level, "  无法解析内部包 %s", pkg)
+				da.unresolved[pkg] = true
+			default:
+				da.tracef(task.level, "  进入内部包 %s (%d 个文件，第 %d 层)", pkg, len(files), task.level+1)
+			}
+			for _, file := range files {
+				// 按真实路径去重，避免通过符号链接重复分析或形成死循环
+				if key := realPath(file); !da.visited[key] {
+					da.visited[key] = true
+					queue = append(queue, fileTask{file: file, level: task.level + 1})
 				}
 			}
 		}
@@ -141,116 +523,707 @@ func (da *DependencyAnalyzer) analyzeDependencies(startFile string, deep bool) e
 	return nil
 }
 
-// 打印结果
-func (da *DependencyAnalyzer) printResults(verbose bool, filterType string) {
-	fmt.Println("\n==================== 依赖分析结果 ====================\n")
-
-	// 标准库
-	if len(da.stdlib) > 0 && (filterType == "all" || filterType == "stdlib") {
-		fmt.Printf("📦 标准库 (%d):\n", len(da.stdlib))
-		stdlib := make([]string, 0, len(da.stdlib))
-		for pkg := range da.stdlib {
-			stdlib = append(stdlib, pkg)
-		}
-		sort.Strings(stdlib)
-		for _, pkg := range stdlib {
-			if verbose {
-				fmt.Printf("  ✓ %s\n", pkg)
-			} else {
-				fmt.Printf("  %s\n", pkg)
-			}
+// 解析符号链接得到真实路径，失败时返回原路径
+func realPath(p string) string {
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		return resolved
+	}
+	return p
+}
+
+// 查找内部包目录下的所有非测试 .go 文件 (目录可以是符号链接)
+func (da *DependencyAnalyzer) packageFiles(pkg string) []string {
+	return da.buildFiles(goFilesInDir(da.pkgDir(pkg), da.allFiles))
+}
+
+// 获取内部包对应的目录，导入路径使用 / 分隔，需转换为系统路径分隔符
+func (da *DependencyAnalyzer) pkgDir(pkg string) string {
+	if m, ok := da.moduleForPkg(pkg); ok {
+		if pkg == m.Path {
+			return m.Dir
 		}
-		fmt.Println()
+		return filepath.Join(m.Dir, filepath.FromSlash(strings.TrimPrefix(pkg, m.Path+"/")))
+	}
+	// -internal-prefix 与 go.mod 中的模块路径不同时，前缀对应项目根目录
+	root := da.goModPath
+	if da.internalPrefix != "" && deps.HasPathPrefix(pkg, da.internalPrefix) {
+		root = strings.TrimSuffix(da.internalPrefix, "/")
+	}
+	if pkg == root {
+		return da.projectPath
+	}
+	pkgPath := strings.TrimPrefix(pkg, root+"/")
+	return filepath.Join(da.projectPath, filepath.FromSlash(pkgPath))
+}
+
+// 查找目录下的所有 .go 文件，includeTests 为 false 时跳过测试文件
+func goFilesInDir(fullPath string, includeTests bool) []string {
+	// 检查是否是目录
+	info, err := os.Stat(fullPath)
+	if err != nil || !info.IsDir() {
+		return nil
 	}
 
-	// 第三方库
-	if len(da.thirdParty) > 0 && (filterType == "all" || filterType == "third-party") {
-		fmt.Printf("🌐 第三方库 (%d):\n", len(da.thirdParty))
-		thirdParty := make([]string, 0, len(da.thirdParty))
-		for pkg := range da.thirdParty {
-			thirdParty = append(thirdParty, pkg)
+	// 查找目录中的所有 .go 文件 (汇编 .s 等其他文件不参与分析)
+	files, err := filepath.Glob(filepath.Join(fullPath, "*.go"))
+	if err != nil {
+		return nil
+	}
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		// 跳过测试文件
+		if !includeTests && strings.HasSuffix(file, "_test.go") {
+			continue
 		}
-		sort.Strings(thirdParty)
-		for _, pkg := range thirdParty {
-			if verbose {
-				fmt.Printf("  ✓ %s\n", pkg)
-			} else {
-				fmt.Printf("  %s\n", pkg)
-			}
+		result = append(result, file)
+	}
+	return result
+}
+
+// 依赖分类
+type categoryInfo struct {
+	Key   string // 与 -type 参数的取值一致
+	Title string
+	Icon  string
+	Color string
+}
+
+var (
+	catStdlib     = categoryInfo{Key: "stdlib", Title: "标准库", Icon: "📦", Color: colorGreen}
+	catExtended   = categoryInfo{Key: "extended", Title: "扩展库", Icon: "🧩", Color: colorCyan}
+	catThirdParty = categoryInfo{Key: "third-party", Title: "第三方库", Icon: "🌐", Color: colorYellow}
+	catInternal   = categoryInfo{Key: "internal", Title: "内部包", Icon: "🏠", Color: colorBlue}
+	catCgo        = categoryInfo{Key: "cgo", Title: "cgo", Icon: "⚙️"}
+)
+
+// 当前启用的分类 (不含 cgo)，按展示顺序排列
+func (da *DependencyAnalyzer) categories() []categoryInfo {
+	cats := []categoryInfo{catStdlib}
+	if da.splitXTools {
+		cats = append(cats, catExtended)
+	}
+	cats = append(cats, catThirdParty)
+	cats = append(cats, da.customCats...)
+	return append(cats, catInternal)
+}
+
+// 包所属分类的标题、图标和颜色
+func (da *DependencyAnalyzer) categoryOf(pkg string) categoryInfo {
+	key := da.category(pkg)
+	for _, cat := range append(da.categories(), catCgo) {
+		if cat.Key == key {
+			return cat
 		}
-		fmt.Println()
 	}
+	return categoryInfo{Key: key, Title: key, Icon: customCategoryIcon}
+}
 
-	// 内部包
-	if len(da.internal) > 0 && (filterType == "all" || filterType == "internal") {
-		fmt.Printf("🏠 内部包 (%d):\n", len(da.internal))
-		internal := make([]string, 0, len(da.internal))
-		for pkg := range da.internal {
-			internal = append(internal, pkg)
+// 获取分类对应的包集合
+func (da *DependencyAnalyzer) categorySet(key string) map[string]bool {
+	switch key {
+	case "stdlib":
+		return da.stdlib
+	case "extended":
+		return da.extended
+	case "third-party":
+		return da.thirdParty
+	case "internal":
+		return da.internal
+	case "cgo":
+		return da.cgo
+	}
+	return da.custom[key]
+}
+
+// 所有分类的包总数 (不含 cgo)
+func (da *DependencyAnalyzer) total() int {
+	total := 0
+	for _, cat := range da.categories() {
+		total += len(da.categorySet(cat.Key))
+	}
+	return total
+}
+
+// 获取包所属的分类
+func (da *DependencyAnalyzer) category(pkg string) string {
+	if da.cgo[pkg] {
+		return "cgo"
+	}
+	for _, cat := range da.categories() {
+		if da.categorySet(cat.Key)[pkg] {
+			return cat.Key
 		}
-		sort.Strings(internal)
-		for _, pkg := range internal {
-			if verbose {
-				fmt.Printf("  ✓ %s\n", pkg)
-			} else {
-				fmt.Printf("  %s\n", pkg)
+	}
+	return "internal"
+}
+
+// 分类的展示顺序，cgo 排在最后
+func (da *DependencyAnalyzer) categoryRank(key string) int {
+	cats := da.categories()
+	for i, cat := range cats {
+		if cat.Key == key {
+			return i
+		}
+	}
+	return len(cats)
+}
+
+// 按指定方式排序包列表: name (包名) | count (被导入次数，相同时按包名) | category (分类，相同时按包名)
+func (da *DependencyAnalyzer) sortPackages(pkgs []string, sortBy string) {
+	sort.Slice(pkgs, func(i, j int) bool {
+		switch sortBy {
+		case "count":
+			if ci, cj := da.counts[pkgs[i]], da.counts[pkgs[j]]; ci != cj {
+				return ci > cj
+			}
+		case "category":
+			if ci, cj := da.categoryRank(da.category(pkgs[i])), da.categoryRank(da.category(pkgs[j])); ci != cj {
+				return ci < cj
 			}
 		}
+		return pkgs[i] < pkgs[j]
+	})
+}
+
+// 打印选项
+type printOptions struct {
+	verbose    bool
+	deep       bool
+	filterType string
+	sortBy     string
+	color      bool
+	quiet      bool // 省略装饰性的分隔线
+	topN       int  // 每个分类最多列出的包数量，0 表示不限制
+	statsOnly  bool // 只输出统计信息 (包已在 -stream 时逐个输出)
+}
+
+// 打印分节标题，quiet 模式下省略
+func printSectionHeader(title string, quiet bool) {
+	if !quiet {
+		fmt.Printf("\n==================== %s ====================\n", title)
+	}
+}
+
+// 打印分节结束线，quiet 模式下省略
+func printSectionFooter(quiet bool) {
+	if !quiet {
+		fmt.Println("===================================================")
+	}
+}
+
+// 打印单个包
+func (da *DependencyAnalyzer) printPackage(pkg string, opts printOptions) {
+	line := colorize(pkg, da.categoryOf(pkg).Color, opts.color)
+	if opts.sortBy == "count" {
+		line = fmt.Sprintf("%s (%d)", line, da.counts[pkg])
+	}
+	if opts.deep && da.isTransitive(pkg) {
+		line += msg.Transit
+	} else if opts.deep && !da.direct[pkg] {
+		line += msg.Indirect
+	}
+	if syn, ok := da.synopses[pkg]; ok {
+		if syn == "" {
+			syn = msg.NoDoc
+		}
+		line += " — " + syn
+	}
+	if opts.verbose {
+		fmt.Printf("  ✓ %s\n", line)
+	} else {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// 打印结果
+func (da *DependencyAnalyzer) printResults(opts printOptions) {
+	filterType := opts.filterType
+	if !opts.quiet && !opts.statsOnly {
+		fmt.Printf("\n==================== %s ====================\n\n", msg.Results)
+	}
+
+	for _, cat := range da.categories() {
+		set := da.categorySet(cat.Key)
+		if opts.statsOnly || len(set) == 0 || (filterType != "all" && filterType != cat.Key) {
+			continue
+		}
+		fmt.Println(colorize(fmt.Sprintf("%s %s (%d):", cat.Icon, cat.Title, len(set)), cat.Color, opts.color))
+		pkgs := make([]string, 0, len(set))
+		for pkg := range set {
+			pkgs = append(pkgs, pkg)
+		}
+		da.sortPackages(pkgs, opts.sortBy)
+		shown := pkgs
+		if opts.topN > 0 && len(pkgs) > opts.topN {
+			shown = pkgs[:opts.topN]
+		}
+		for _, pkg := range shown {
+			da.printPackage(pkg, opts)
+		}
+		if len(shown) < len(pkgs) {
+			fmt.Printf(msg.More, len(pkgs)-len(shown))
+		}
+		fmt.Println()
+	}
+
+	// cgo
+	if len(da.cgo) > 0 && filterType == "all" && !opts.statsOnly {
+		fmt.Println(msg.Cgo)
+		for pkg := range da.cgo {
+			da.printPackage(pkg, opts)
+		}
 		fmt.Println()
 	}
 
 	// 统计
 	if filterType == "all" {
-		total := len(da.stdlib) + len(da.thirdParty) + len(da.internal)
-		fmt.Println("==================== 统计信息 ====================")
-		fmt.Printf("总计: %d 个包\n", total)
+		total := da.total()
+		if !opts.quiet {
+			fmt.Printf("==================== %s ====================\n", msg.Stats)
+		}
+		fmt.Printf(msg.Total, total)
 		if total > 0 {
-			fmt.Printf("  - 标准库: %d (%.1f%%)\n", len(da.stdlib), float64(len(da.stdlib))/float64(total)*100)
-			fmt.Printf("  - 第三方库: %d (%.1f%%)\n", len(da.thirdParty), float64(len(da.thirdParty))/float64(total)*100)
-			fmt.Printf("  - 内部包: %d (%.1f%%)\n", len(da.internal), float64(len(da.internal))/float64(total)*100)
+			for _, cat := range da.categories() {
+				n := len(da.categorySet(cat.Key))
+				fmt.Printf("  - %s: %d (%.1f%%)\n", cat.Title, n, float64(n)/float64(total)*100)
+			}
 		}
-		fmt.Println("===================================================")
+		if len(da.cgo) > 0 {
+			fmt.Println(msg.CgoNote)
+		}
+		if opts.deep {
+			direct := 0
+			for _, cat := range da.categories() {
+				for pkg := range da.categorySet(cat.Key) {
+					if da.direct[pkg] {
+						direct++
+					}
+				}
+			}
+			fmt.Printf(msg.Direct, direct, total-direct)
+			if d, ok := distribution(da.fileImportCounts()); ok {
+				fmt.Printf(msg.PerFile, d.Mean, d.Median, d.Max, d.MaxOf)
+			}
+			if d, ok := distribution(da.pkgImportCounts()); ok {
+				fmt.Printf(msg.PerPkg, d.Mean, d.Median, d.Max, d.MaxOf)
+			}
+		}
+		printSectionFooter(opts.quiet)
 	} else {
 		// 只显示指定类型的统计
-		fmt.Println("==================== 统计信息 ====================")
-		switch filterType {
-		case "stdlib":
-			fmt.Printf("标准库: %d 个包\n", len(da.stdlib))
-		case "third-party":
-			fmt.Printf("第三方库: %d 个包\n", len(da.thirdParty))
-		case "internal":
-			fmt.Printf("内部包: %d 个包\n", len(da.internal))
+		if !opts.quiet {
+			fmt.Printf("==================== %s ====================\n", msg.Stats)
 		}
-		fmt.Println("===================================================")
+		for _, cat := range da.categories() {
+			if cat.Key == filterType {
+				fmt.Printf(msg.Count, cat.Title, len(da.categorySet(cat.Key)))
+			}
+		}
+		printSectionFooter(opts.quiet)
+	}
+}
+
+// 单行摘要: 总数和各分类的数量 (以分类名标识)，filterType 不为 all 时只包含该分类
+func (da *DependencyAnalyzer) compactSummary(filterType string) string {
+	total := 0
+	var parts []string
+	for _, cat := range da.categories() {
+		if filterType != "all" && filterType != cat.Key {
+			continue
+		}
+		n := len(da.categorySet(cat.Key))
+		total += n
+		parts = append(parts, fmt.Sprintf("%s %d", cat.Key, n))
+	}
+	return fmt.Sprintf("deps: %d (%s)", total, strings.Join(parts, ", "))
+}
+
+// 打印分析对象和模式说明
+func (da *DependencyAnalyzer) printPreamble(pkgPath string, entries []string, deep bool) {
+	if pkgPath != "" {
+		fmt.Printf("分析包: %s (%d 个文件)\n", pkgPath, len(entries))
+	} else if len(entries) == 1 {
+		fmt.Printf("分析文件: %s\n", entries[0])
+	} else {
+		fmt.Printf("分析文件 (%d):\n", len(entries))
+		for _, entry := range entries {
+			fmt.Printf("  %s\n", entry)
+		}
+	}
+	if names := da.entryPackages(entries); len(names) > 0 {
+		fmt.Printf("包名: %s\n", strings.Join(names, ", "))
+	}
+	if deep {
+		fmt.Println("模式: 深度分析（递归内部包）")
+	} else {
+		fmt.Println("模式: 浅层分析（仅直接依赖）")
 	}
 }
 
+// 可重复指定的字符串参数，每次的值也可以用逗号分隔 (配置文件中的列表以逗号连接后传入)
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSuffix(strings.TrimSpace(item), "/"); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
 func main() {
 	// 命令行参数
-	filePath := flag.String("f", "", "入口文件路径 (必填)")
+	filePath := flag.String("f", "", "入口文件路径，支持通配符如 'service/**/main.go'，也可以是包目录或包导入路径 (包内所有非测试文件作为入口) (必填)")
+	pkgPath := flag.String("pkg", "", "按导入路径指定要分析的包，分析包内所有非测试文件 (可代替 -f)")
 	deep := flag.Bool("d", false, "深度分析，递归分析内部包的依赖")
 	verbose := flag.Bool("v", false, "详细输出")
-	filterType := flag.String("type", "all", "只显示指定类型的依赖: stdlib (标准库) | third-party (第三方库) | internal (内部包) | all (全部)")
+	filterType := flag.String("type", "all", "只显示指定类型的依赖: stdlib (标准库) | third-party (第三方库) | internal (内部包) | extended (扩展库，需 -split-xtools) | all (全部)")
+	sortBy := flag.String("sort", "name", "排序方式: name (包名) | count (被导入次数) | category (分类)")
+	colorMode := flag.String("color", "auto", "彩色输出: auto (自动检测终端和 NO_COLOR) | always | never")
+	maxThirdParty := flag.Int("max-third-party", 0, "第三方库数量上限，超出时以非零状态退出 (0 表示不限制)")
+	maxTotal := flag.Int("max-total", 0, "依赖总数上限，超出时以非零状态退出 (0 表示不限制)")
+	maxThirdPartyPct := flag.Float64("max-third-party-pct", 0, "第三方库占依赖总数的百分比上限，超出时以非零状态退出 (0 表示不限制)")
+	depthReport := flag.Bool("depth-report", false, "按与入口文件的最小导入距离分层列出内部包 (隐含 -d)")
+	sarifFile := flag.String("sarif", "", "将导入循环、缺失的 require 等检查结果以 SARIF 2.1.0 格式写入指定文件")
+	baselineFile := flag.String("baseline", "", "与之前生成的 JSON 基线文件对比，存在差异时以非零状态退出")
+	baselineUpdate := flag.Bool("baseline-update", false, "用本次结果重新生成 -baseline 指定的基线文件")
+	perFile := flag.Bool("per-file", false, "额外按文件列出每个文件导入的包")
+	groupStd := flag.Bool("group-stdlib", false, "按第一段路径 (crypto、net、encoding 等) 分组统计标准库")
+	quiet := flag.Bool("quiet", false, "只输出结果，省略分析对象、模式说明和分隔线")
+	strict := flag.Bool("strict", false, "严格模式: 存在无法解析的内部包、库包导入了命令包或 replace 指向无效的本地目录时以非零状态退出")
+	excludes := flag.String("exclude", "", "排除匹配的包，逗号分隔的通配符模式 (同时排除其子包)，如 'github.com/gogo/*'")
+	internalPrefix := flag.String("internal-prefix", "", "内部包前缀，设置后代替 go.mod 中的模块路径判断内部包")
+	configFile := flag.String("config", "", "配置文件路径 (默认读取项目根目录下的 "+defaultConfigFile+")")
+	splitXTools := flag.Bool("split-xtools", false, "将 golang.org/x 下的包单独归为扩展库 (extended)，默认计入第三方库")
+	requiredModules := flag.Bool("required-modules", false, "以 require 块格式列出实际导入的第三方模块，并列出 go.mod 中未被导入的模块")
+	tui := flag.Bool("tui", false, "在终端中交互式浏览分析结果 (配合 -d 可展开内部包的导入)")
+	skipGenerated := flag.Bool("skip-generated", false, "跳过带有 \"// Code generated ... DO NOT EDIT.\" 标记的生成文件 (-v 时列出跳过的文件)")
+	since := flag.String("since", "", "只分析自指定 git 引用以来改动的文件，报告新引入的第三方库，存在时以非零状态退出 (可代替 -f)")
+	sizeEstimate := flag.Bool("size-estimate", false, "按模块缓存中的代码行数估算每个第三方模块的体量")
+	outFile := flag.String("o", "", "将报告写入指定文件而不是标准输出，自动创建父目录")
+	layersFile := flag.String("layers", "", "分层规则文件，检查内部包之间的导入方向，存在违规时以非零状态退出 (隐含 -d)")
+	topN := flag.Int("top-n", 0, "每个分类最多列出前 N 个包 (配合 -sort count 使用)，统计信息不受影响")
+	allFiles := flag.Bool("all-files", false, "分析所有 .go 文件，包括测试文件并忽略构建约束，得到所有可能导入的并集")
+	showScore := flag.Bool("score", false, "计算 0~100 的依赖健康度评分并列出各项明细 (建议配合 -d)")
+	scoreWeights := flag.String("score-weights", "", "覆盖健康度评分的权重，如 'third-party=40,cycles=30,domains=15,versions=15'")
+	match := flag.String("match", "", "只保留路径匹配该正则表达式的包 (分类后应用，-exclude 优先)，如 'grpc|protobuf'")
+	checkMissing := flag.Bool("check-missing", false, "检查第三方库是否都已在 go.mod 中 require，存在缺失时以非零状态退出")
+	explain := flag.String("explain", "", "打印从入口文件到指定包的最短导入链 (隐含 -d)")
+	versionSummary := flag.Bool("version-summary", false, "查询 go.mod 中 require 版本的发布时间 (模块缓存或模块代理)，列出最旧和最新的依赖")
+	staleMonths := flag.Int("stale-months", 12, "配合 -version-summary，标记发布时间超过该月数的版本")
+	classifyRulesFile := flag.String("classify-rules", "", "自定义分类规则文件 (每行 \"<包路径模式> -> <分类>\")，优先于内置分类，可定义新的分类")
+	forbidStdlib := flag.String("forbid-stdlib", "", "禁止导入的标准库，逗号分隔 (同时禁止子包)，如 'os/exec,unsafe,net'，存在导入时以非零状态退出")
+	allowDeps := flag.String("allow-deps", "", "只允许导入匹配的外部依赖，逗号分隔的通配符模式 (含子包)，如 'github.com/acme/*,golang.org/x/*'，存在其他外部依赖时以非零状态退出")
+	denyDeps := flag.String("deny-deps", "", "禁止导入匹配的外部依赖，逗号分隔的通配符模式 (含子包)，优先于 -allow-deps，存在导入时以非零状态退出")
+	blame := flag.Bool("blame", false, "用 git blame 找出每个第三方库最早的导入行，列出引入它的提交、日期和作者")
+	matrix := flag.String("matrix", "", "按多个 GOOS/GOARCH 的构建约束分别分析，对比平台共有和平台相关的外部依赖，如 '"+defaultMatrix+"'")
+	trace := flag.Bool("trace", false, "在标准错误中逐步输出分析过程: 解析的文件、找到的导入及其分类、进入的内部包 (按层级缩进)")
+	findUnreachable := flag.Bool("find-unreachable", false, "从所有入口文件深度分析后，列出项目中没有被任何入口导入的内部包 (隐含 -d)，如 -f 'cmd/*/main.go'")
+	lang := flag.String("lang", "zh", "主报告 (分类标题和统计信息) 的语言: zh | en")
+	labelStdlib := flag.String("label-stdlib", "", "自定义标准库分类的标题")
+	labelThirdParty := flag.String("label-thirdparty", "", "自定义第三方库分类的标题")
+	labelInternal := flag.String("label-internal", "", "自定义内部包分类的标题")
+	merge := flag.Bool("merge", false, "合并参数中的多个 JSON 报告 (如 -baseline 生成的文件)，输出并集、重新计算的统计和每个第三方库的使用服务")
+	normalize := flag.Bool("normalize", false, "规范化输出中的导入路径: 托管域名转为小写并去掉多余的路径分隔，便于对比不同环境生成的报告")
+	leaves := flag.Bool("leaves", false, "列出不导入任何其他内部包的内部包，便于拆分为独立模块 (隐含 -d)")
+	roots := flag.Bool("roots", false, "列出没有被其他内部包导入的内部包 (隐含 -d)")
+	archive := flag.String("archive", "", "分析 .zip 或 .tar.gz 源码包: 解压到临时目录并以其中的 go.mod 所在目录为项目根目录，-f 相对该目录 (默认为模块内除 vendor、testdata 和嵌套模块外的所有包)")
+	findRemovable := flag.Bool("find-removable", false, "列出 go.mod 中标记为 indirect 且没有任何包被导入的模块，作为 go mod tidy 的清理候选")
+	stream := flag.Bool("stream", false, "每发现一个新包立即输出 (以分类为前缀)，分析结束后只输出统计信息")
+	listFiles := flag.Bool("list-files", false, "只列出将要解析的文件 (已应用测试文件、-exclude、-skip-generated 等过滤)，不输出依赖分析结果")
+	gha := flag.Bool("gha", false, "GitHub Actions 集成: 为检查结果输出 ::error / ::warning 工作流命令，并将 Markdown 摘要追加到 $GITHUB_STEP_SUMMARY")
+	tree := flag.Bool("tree", false, "以缩进的树列出从入口包开始的递归导入层级，标记导入循环 (↻) 和已展开过的包 (…) (隐含 -d)")
+	templateText := flag.String("template", "", "用 Go text/template 渲染分析结果 (以 @ 开头时从文件读取模板)，如 '{{range .Report.ThirdParty}}{{.}}{{\"\\n\"}}{{end}}'")
+	formatName := flag.String("format", "text", "输出格式，多个时逗号分隔并配合 -o 前缀分别写入: text (默认的文本报告) | json (完整的分类结果和统计，结构见 -print-schema) | dot (Graphviz 依赖图，按分类着色，建议配合 -d) | mermaid (内部包关系的 Mermaid graph TD 图，隐含 -d) | html (可折叠导入树和可搜索表格的单文件报告，配合 -o) | csv (每个包一行的依赖清单) | sarif (检查结果，同 -sarif) | cyclonedx (第三方模块的 CycloneDX SBOM) | spdx (第三方模块的 SPDX 2.3 清单) | markdown (摘要和每个分类的表格) | graphml (带节点和边属性的依赖图) | plantuml (按顶层目录分组的组件图，隐含 -d) | d2 (按顶层目录分容器的 D2 图，隐含 -d) | junit (每条检查规则一个测试用例) | ndjson (边分析边逐行输出包和导入关系，适合流式处理) | xlsx (每个分类一个工作表的 Excel 工作簿，配合 -o)")
+	showSchema := flag.Bool("print-schema", false, "打印 JSON 报告 (如 -baseline 基线文件) 的 JSON Schema (draft 2020-12) 后退出，不需要 -f")
+	deepThirdParty := flag.Bool("deep-third-party", false, "深度分析时也递归第三方包: 从模块缓存中找到源码并分析其导入，只通过第三方包引入的包标记为 [传递] (隐含 -d，建议配合 -max-depth)")
+	maxDepth := flag.Int("max-depth", 0, "深度分析的最大层数 (入口文件直接导入的包为第 1 层)，超过后不再深入 (0 表示不限制)")
+	indexFile := flag.String("index", "", "项目范围的导入索引文件 (如 .depcache.json)，未改动的文件直接使用索引中的导入，分析后更新索引")
+	rebuildIndex := flag.Bool("rebuild-index", false, "忽略 -index 中已有的内容，重新解析所有文件并重建索引")
+	byEntry := flag.Bool("by-entry", false, "多个入口文件时，先分别列出每个入口的分类结果，再输出合并的结果")
+	compact := flag.Bool("compact", false, "只输出一行摘要，如 \"deps: 42 (stdlib 20, third-party 15, internal 7)\"，遵循 -type")
+	badgeFile := flag.String("badge", "", "生成 shields.io 风格的 SVG 徽章，显示外部依赖包数和健康度评分 (如 \"deps: 42 | health: 87\")，建议配合 -d")
+	var alsoInternal stringList
+	flag.Var(&alsoInternal, "also-internal", "额外视为内部包的路径前缀，可重复指定或逗号分隔，在 go.mod 模块路径 (或 -internal-prefix) 之外补充")
+	stdinName := flag.String("stdin-name", defaultStdinName, "配合 -f -，为标准输入中的源码指定虚拟文件名 (相对当前目录，决定所属的包)")
+	netTimeout := flag.Duration("timeout", defaultNetTimeout, "网络请求 (如查询模块代理) 的单次超时时间")
+	netRetries := flag.Int("retries", defaultNetRetries, "网络请求失败 (网络错误、429、5xx) 时的重试次数，按指数退避等待")
+	offline := flag.Bool("offline", false, "离线模式: 不访问网络，依赖网络的功能只使用本地数据 (如模块缓存) 并在报告中注明")
+	checkVisibility := flag.Bool("check-internal-visibility", false, "按 Go 的 internal 目录规则检查导入 (只能被 internal 父目录下的包导入)，存在违规时以非零状态退出")
+	matrixCSV := flag.Bool("matrix-csv", false, "只以 CSV 输出内部包的邻接矩阵 (行列为按包名排序的包路径，存在导入时为 1)，用于数值分析工具 (隐含 -d)")
+	findOverlap := flag.Bool("find-overlap", false, "按内置的知识表找出用途相同的多个第三方模块 (如同时使用 logrus 和 zap)，便于统一依赖")
+	synopsis := flag.Bool("synopsis", false, "在第三方库后显示模块缓存中包文档的第一句 (-v 时也显示标准库)")
+	branchDiffRange := flag.String("branch-diff", "", "对比两个 git 引用处整个模块实际导入的第三方模块 (新增、移除和版本变化)，格式 <base>..<head> (可代替 -f)")
+	showVersion := flag.Bool("version", false, "打印工具版本、Git 提交和构建时间后退出")
+	flagTestImports := flag.Bool("flag-test-imports", false, "检查生产代码 (非 _test.go 文件) 是否导入了测试辅助包，存在时以非零状态退出")
+	testImportPatterns := flag.String("test-import-patterns", defaultTestImportPatterns, "配合 -flag-test-imports，测试辅助包的路径特征 (逗号分隔的子串)")
+	splitOutput := flag.String("split-output", "", "将每个分类的包分别写入指定目录下的 stdlib.txt、third-party.txt、internal.txt 等文件 (每行一个，按包名排序)")
+	importOrder := flag.Bool("check-import-order", false, "检查每个文件的导入是否按标准库、第三方库、内部包分组并在组内排序 (goimports 约定)，存在违规时以非零状态退出")
+	popularity := flag.Bool("popularity", false, "按导入文件数从多到少列出每个包被多少个不同的文件导入，找出改动影响面最大的依赖")
+	downloadList := flag.Bool("download-list", false, "只逐行输出第三方模块的 module@version，可配合 xargs go mod download 预先填充模块缓存")
+	// 子命令 check_deps schema 等同于 -print-schema
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		os.Args = append([]string{os.Args[0], "-print-schema"}, os.Args[2:]...)
+	}
 	flag.Parse()
 
-	if *filePath == "" {
-		fmt.Println("错误: 请指定入口文件路径")
+	if *showVersion {
+		printVersion()
+		os.Exit(0)
+	}
+
+	if *showSchema {
+		if err := printSchema(); err != nil {
+			fmt.Printf("错误: 生成 JSON Schema 失败: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// 获取项目根目录（假设脚本在 scripts 目录下）
+	projectPath, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("错误: 无法获取当前目录: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 如果当前目录是 scripts，则向上一级
+	if filepath.Base(projectPath) == "scripts" {
+		projectPath = filepath.Dir(projectPath)
+	}
+
+	// 读取配置文件，命令行参数优先
+	if *configFile != "" {
+		if err := applyConfig(*configFile); err != nil {
+			fmt.Printf("错误: 读取配置文件失败: %v\n", err)
+			os.Exit(1)
+		}
+	} else if defaultPath := filepath.Join(projectPath, defaultConfigFile); fileExists(defaultPath) {
+		if err := applyConfig(defaultPath); err != nil {
+			fmt.Printf("错误: 读取配置文件 %s 失败: %v\n", defaultPath, err)
+			os.Exit(1)
+		}
+	}
+
+	if *filePath != "" && *pkgPath != "" {
+		fmt.Println("错误: -f 和 -pkg 不能同时使用")
+		os.Exit(1)
+	}
+
+	if *filePath == "" && *pkgPath == "" && *since == "" && *archive == "" && !*merge && *branchDiffRange == "" {
+		fmt.Println("错误: 请指定入口文件路径或包导入路径")
 		fmt.Println("\n使用方法:")
-		fmt.Println("  go run check_deps.go -f <入口文件路径> [-d] [-v] [-type <类型>]")
+		fmt.Println("  go run check_deps.go -f <入口文件路径> [-d] [-v] [-type <类型>] [-sort <方式>]")
+		fmt.Println("  go run check_deps.go -pkg <包导入路径> [-d] [-v] [-type <类型>] [-sort <方式>]")
 		fmt.Println("\n参数说明:")
-		fmt.Println("  -f     入口文件路径 (必填)")
+		fmt.Println("  -f     入口文件路径 (必填)，支持通配符，** 匹配任意层级目录 (需加引号)")
+		fmt.Println("         也可以是包目录或包导入路径 (如 ./svc/order、example.com/app/svc/order)，包内所有非测试文件都作为入口")
+		fmt.Println("  -pkg   包导入路径，分析包内所有非测试文件 (可代替 -f)")
 		fmt.Println("  -d     深度分析，递归分析内部包的依赖")
 		fmt.Println("  -v     详细输出")
 		fmt.Println("  -type  只显示指定类型的依赖")
 		fmt.Println("         类型: stdlib (标准库) | third-party (第三方库) | internal (内部包) | all (全部，默认)")
+		fmt.Println("  -sort  排序方式")
+		fmt.Println("         方式: name (包名，默认) | count (被导入次数) | category (分类)")
+		fmt.Println("  -color 彩色输出: auto (默认，非终端或设置 NO_COLOR 时关闭) | always | never")
+		fmt.Println("  -max-third-party  第三方库数量上限，超出时退出码为 1")
+		fmt.Println("  -max-total        依赖总数上限，超出时退出码为 1")
+		fmt.Println("  -max-third-party-pct N 第三方库占比上限 (百分比，与统计信息中的占比一致)，超出时退出码为 1")
+		fmt.Println("  -depth-report     按导入层级分组列出内部包 (隐含 -d)")
+		fmt.Println("  -sarif <文件>     将检查结果写入 SARIF 2.1.0 报告，用于代码扫描平台展示")
+		fmt.Println("  -gha              在 GitHub Actions 中运行: 检查结果以 ::error file=...,line=... 标注到代码行，依赖摘要写入步骤摘要 ($GITHUB_STEP_SUMMARY)")
+		fmt.Println("  -baseline <文件>  与 JSON 基线文件对比，报告新增和移除的包，存在差异时退出码为 1")
+		fmt.Println("  -baseline-update 用本次结果重新生成基线文件")
+		fmt.Println("  -per-file         额外按文件列出每个文件导入的包")
+		fmt.Println("  -group-stdlib     按第一段路径分组统计标准库，单段包归入 core (-v 时列出包)")
+		fmt.Println("  -quiet            只输出结果，省略分析对象、模式说明和分隔线")
+		fmt.Println("  -strict           存在无法解析的内部包、库包导入了 package main / cmd/ 下的包或 replace 指向无效的本地目录时退出码为 1")
+		fmt.Println("  -exclude          排除匹配的包，逗号分隔的通配符模式")
+		fmt.Println("  -internal-prefix  内部包前缀，代替 go.mod 中的模块路径")
+		fmt.Println("  -config <文件>    配置文件路径，默认读取项目根目录下的 " + defaultConfigFile + "，命令行参数优先")
+		fmt.Println("  -split-xtools     将 golang.org/x 下的包单独归为扩展库 (extended)")
+		fmt.Println("  -required-modules 以 require 块格式列出实际导入的模块 (-sort count 按包数量排序)")
+		fmt.Println("  -tui              交互式浏览结果: Tab 切换分类，输入文字过滤，Enter 展开内部包")
+		fmt.Println("  -skip-generated   跳过生成的文件 (protobuf、mock 等)，-v 时列出跳过的文件")
+		fmt.Println("  -since <引用>     报告自 git 引用以来改动的文件新引入的第三方库，存在时退出码为 1")
+		fmt.Println("  -size-estimate    按模块缓存 ($GOPATH/pkg/mod) 中的代码行数估算第三方模块体量")
+		fmt.Println("  -o <文件>         将报告写入文件而不是标准输出")
+		fmt.Println("  -layers <文件>    按分层规则检查内部包之间的导入方向 (每行 \"handler -> service, model\")，违规时退出码为 1")
+		fmt.Println("  -top-n N          每个分类只列出前 N 个包，其余以 \"... 还有 M 个\" 省略")
+		fmt.Println("  -all-files        包括测试文件并忽略构建约束，用于完整的依赖审计")
+		fmt.Println("  -score            计算依赖健康度评分 (第三方库占比、导入循环、托管域名数、多主版本冲突)")
+		fmt.Println("  -score-weights    覆盖评分权重，如 'third-party=40,cycles=30,domains=15,versions=15'")
+		fmt.Println("  -match <正则>     只保留路径匹配正则表达式的包，与 -exclude 同时使用时排除优先")
+		fmt.Println("  -check-missing  检查 go.mod 中缺失的 require，存在缺失时退出码为 1")
+		fmt.Println("  -explain <包>     打印从入口文件到该包的最短导入链及每一步的导入位置 (隐含 -d)")
+		fmt.Println("  -version-summary  按发布时间列出 go.mod 中的依赖版本，离线时使用模块缓存和伪版本中的时间")
+		fmt.Println("  -stale-months N   标记发布时间超过 N 个月的版本 (默认 12)")
+		fmt.Println("  -classify-rules <文件> 自定义分类规则 (每行 \"github.com/acme -> partner\")，可定义新分类并用于 -type")
+		fmt.Println("  -forbid-stdlib    禁止导入的标准库 (逗号分隔，含子包)，列出每处导入的文件和行号，存在时退出码为 1")
+		fmt.Println("  -allow-deps / -deny-deps  外部依赖的允许/禁止列表 (逗号分隔的通配符模式，含子包)，违反时退出码为 1")
+		fmt.Println("  -blame            按 git blame 列出引入每个第三方库的提交、日期和作者 (取最早的导入行)")
+		fmt.Println("  -matrix <平台>    按构建约束分别分析多个平台 (逗号分隔的 GOOS/GOARCH)，列出平台相关的外部依赖")
+		fmt.Println("  -trace            在标准错误中输出分析过程，用于排查某个包为什么被 (或没有被) 分析")
+		fmt.Println("  -find-unreachable 列出所有入口都无法到达的内部包，用于发现无用代码 (隐含 -d)")
+		fmt.Println("  -lang zh|en       主报告的分类标题和统计信息使用的语言 (默认 zh)")
+		fmt.Println("  -label-stdlib / -label-thirdparty / -label-internal  自定义分类标题，优先于 -lang")
+		fmt.Println("  -merge <报告...>  合并多个服务的 JSON 报告，服务名取文件名 (文件名相同时取目录名)，如 -merge reports/*.json")
+		fmt.Println("  -normalize        托管域名转为小写、去掉多余的 / 和 ./，使等价环境的输出逐字节一致")
+		fmt.Println("  -leaves           列出叶子内部包 (只依赖标准库和第三方库，隐含 -d)")
+		fmt.Println("  -roots            列出没有被其他内部包导入的内部包 (隐含 -d)")
+		fmt.Println("  -archive <文件>   解压 .zip/.tar.gz 源码包后分析，-f 为包内相对路径，结束后删除临时目录")
+		fmt.Println("  -find-removable   列出没有被导入的 indirect 依赖及其版本 (建议配合 -d)")
+		fmt.Println("  -stream           边分析边输出新发现的包，如 \"[third-party] github.com/foo/bar\"，最后输出统计")
+		fmt.Println("  -list-files       只列出将要解析的文件，用于确认通配符、-pkg 和 -d 选中的文件范围")
+		fmt.Println("  -tree             以树的形式打印导入层级，查看第三方库是经由哪些内部包引入的 (隐含 -d，-type 只保留该分类的叶子)")
+		fmt.Println("  -template <模板>  用 Go text/template 渲染结果 (@文件 从文件读取)，数据字段: Entries、Roots、Categories (Key、Title、Packages: Path、Count、Module、Direct)、Edges、Report (同 -format json)、Deep；函数: join、lower、upper")
+		fmt.Println("  -format text|json 输出格式，json 时只输出结构化的分类结果 (-split-output 也写入 <分类>.json)，便于脚本和 CI 读取")
+		fmt.Println("  -format dot       输出 Graphviz 依赖图 (导入方 -> 被导入包，-type 只保留指向该分类的边)，如 | dot -Tsvg > deps.svg")
+		fmt.Println("  -format mermaid   输出内部包关系的 Mermaid 图 (隐含 -d)，放入 Markdown 的 ```mermaid 代码块即可在 GitHub/GitLab 中渲染")
+		fmt.Println("  -format html -o report.html  生成自包含的 HTML 报告 (可折叠的导入树、可搜索和排序的分类表格)，建议配合 -d")
+		fmt.Println("  -format csv       每个包一行: package,category,module,version,first_import (最早的导入位置，配合 -d 覆盖整个项目)，便于导入表格审计")
+		fmt.Println("  -format sarif     以 SARIF 2.1.0 输出检查结果 (路径相对 git 仓库根目录)，上传到代码扫描后在 PR 的导入行上显示")
+		fmt.Println("  -format cyclonedx 输出 CycloneDX 1.5 JSON SBOM: 实际导入的第三方模块、版本 (go.mod) 和 go.sum 中的 h1: 值 (go:h1 属性)，建议配合 -d")
+		fmt.Println("  -format spdx      输出 SPDX 2.3 JSON: 第三方模块的版本、供应方 (按模块路径推断) 和模块代理下载地址，建议配合 -d")
+		fmt.Println("  -format markdown  输出 Markdown 报告 (统计摘要和每个分类的表格)，可直接贴到 PR 描述或由 CI 机器人评论")
+		fmt.Println("  -format graphml   输出 GraphML 依赖图 (节点: 分类、模块、代码行数、是否直接导入；边: 导入位置数)，用 Gephi 或 yEd 浏览大图")
+		fmt.Println("  -format plantuml  输出 PlantUML 组件图: 内部包按顶层目录分为 package，箭头为目录之间的依赖及导入数 (隐含 -d)")
+		fmt.Println("  -format d2        输出 D2 图: 每个顶层目录一个容器，边为内部包之间的导入 (隐含 -d)，如 | d2 - deps.svg")
+		fmt.Println("  -format junit     以 JUnit XML 输出检查结果: 每条规则一个测试用例，有问题时失败 (警告只写入 system-out)，未开启的检查记为跳过")
+		fmt.Println("  -format ndjson    边分析边逐行输出 JSON 记录: {\"type\":\"package\"} 为新发现的包，{\"type\":\"import\"} 为导入关系及位置，最后一行 {\"type\":\"summary\"} 为统计，适合大型仓库的流式处理")
+		fmt.Println("  -format xlsx -o deps.xlsx  生成 Excel 工作簿: 汇总表 (入口和统计) 加上每个分类一个工作表，第三方库带有模块和版本列")
+		fmt.Println("  -format json,dot -o reports/deps  一次分析输出多个格式，分别写入 reports/deps.json、reports/deps.dot (-o 为目录时写入 deps.<扩展名>)")
+		fmt.Println("  -print-schema     输出 JSON 报告的 JSON Schema，用于校验报告或生成类型定义")
+		fmt.Println("  schema            子命令，同 -print-schema，如 check_deps schema > report.schema.json；报告的 schemaVersion 字段与 Schema 的版本一致")
+		fmt.Println("  -deep-third-party 同时递归第三方包 (读取模块缓存，需先 go mod download)，查看某个依赖传递引入的全部包")
+		fmt.Println("  -max-depth <N>    深度分析最多递归 N 层，避免 -deep-third-party 时依赖图过大")
+		fmt.Println("  -index <文件>     跨次运行复用的导入索引 (按修改时间、大小和内容哈希判断改动)，可在 CI 中缓存")
+		fmt.Println("  -rebuild-index    重建 -index 指定的索引")
+		fmt.Println("  -by-entry         按入口文件分别列出依赖 (如对比多个 cmd/*/main.go)，之后仍输出合并结果")
+		fmt.Println("  -compact          只输出一行摘要 (总数和各分类数量)，用于提交钩子或通知")
+		fmt.Println("  -badge <文件>     生成可嵌入 README 的 SVG 徽章 (外部依赖包数和 -score 健康度评分，按评分着色)")
+		fmt.Println("  -also-internal <前缀> 将同一仓库中其他模块路径下的包也归为内部包 (可重复)，与 -internal-prefix 不同，不替换默认判断")
+		fmt.Println("  -f - [-stdin-name <文件>] 从标准输入读取 Go 源码 (而不是文件列表)，如 pbpaste | check_deps -f -")
+		fmt.Println("  -timeout 10s / -retries 2  网络请求的超时时间和重试次数 (指数退避)，用于 -version-summary 等查询模块代理的功能")
+		fmt.Println("  -offline          不访问网络，-version-summary 只使用模块缓存和伪版本中的时间")
+		fmt.Println("  -check-internal-visibility 列出从 internal 父目录之外导入 .../internal/... 包的位置，违规时退出码为 1 (建议配合 -d)")
+		fmt.Println("  -matrix-csv       只输出内部包的 N×N 邻接矩阵 CSV，行导入列时为 1 (隐含 -d)")
+		fmt.Println("  -find-overlap      列出用途重叠的第三方模块组 (日志、YAML、HTTP 路由等，建议配合 -d)")
+		fmt.Println("  -synopsis         在第三方库后显示包文档的第一句 (读取模块缓存，需先 go mod download)，-v 时包括标准库")
+		fmt.Println("  -branch-diff <base>..<head> 分别深度分析两个引用处的整个模块，列出新增、移除和版本变化的第三方模块")
+		fmt.Println("  -version          打印版本、Git 提交和构建时间 (可通过 -ldflags -X main.version=... 注入) 后退出")
+		fmt.Println("  -flag-test-imports 列出非测试文件中对测试辅助包 (路径包含 -test-import-patterns，默认 " + defaultTestImportPatterns + ") 的导入，存在时退出码为 1")
+		fmt.Println("  -split-output <目录> 按分类写入 <分类>.txt (每行一个包)，目录不存在时自动创建")
+		fmt.Println("  -check-import-order 检查导入分组 (标准库、第三方库、内部包，空行分隔) 和组内排序，违规时退出码为 1")
+		fmt.Println("  -popularity       按导入该包的不同文件数降序列出包 (配合 -d 统计整个项目，-top-n 限制数量)")
+		fmt.Println("  -download-list    只输出第三方模块的 module@version 列表 (按模块去重)，用于离线环境预下载")
 		fmt.Println("\n示例:")
 		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go")
 		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -d")
 		fmt.Println("  go run check_deps.go -f service/admin/api/admin.go -d -v")
+		fmt.Println("  go run check_deps.go -f 'service/**/main.go' -d")
+		fmt.Println("  go run check_deps.go -pkg xiaoiron.com/admin/service/manager/rpc -d")
 		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -type stdlib")
 		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -type third-party")
+		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -d -sort count")
+		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -d -check-missing")
+		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -d -max-third-party 50 -max-total 200")
+		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -d -baseline deps.lock.json")
+		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -explain github.com/gogo/protobuf/proto")
+		fmt.Println("  go run check_deps.go -f service/manager/rpc/manager.go -d -download-list | xargs go mod download")
+		fmt.Println("  go run check_deps.go -archive vendor-sdk.tar.gz -d")
+		fmt.Println("  go run check_deps.go -since origin/main")
+		fmt.Println("  go run check_deps.go -branch-diff v1.2.0..main")
+		os.Exit(1)
+	}
+
+	// 验证输出格式 (需要在判断是否隐含 -d 之前)，多个格式时以 -o 为文件名前缀分别写入
+	formats, err := parseFormats(*formatName)
+	if err != nil {
+		fmt.Printf("错误: %v\n", err)
+		fmt.Printf("支持的输出格式: %s\n", formatNames())
 		os.Exit(1)
 	}
+	format := formats[0]
+	multiFormat := len(formats) > 1
+	if multiFormat && *outFile == "" {
+		fmt.Println("错误: -format 指定多个格式时需要 -o <文件名前缀或目录>，如 -format json,dot -o reports/deps")
+		os.Exit(1)
+	}
+	if format.Binary && !multiFormat && *outFile == "" && isTerminal(os.Stdout) {
+		fmt.Printf("错误: %s 是二进制格式，需要 -o 写入文件，如 -format %s -o deps%s\n", format.Name, format.Name, format.Ext)
+		os.Exit(1)
+	}
+	deepFormat := false
+	for _, f := range formats {
+		deepFormat = deepFormat || f.Deep
+	}
+	if *templateText != "" {
+		if format.Name != "text" {
+			fmt.Println("错误: -template 不能与 -format 同时使用")
+			os.Exit(1)
+		}
+		tmpl, err := parseOutputTemplate(*templateText)
+		if err != nil {
+			fmt.Printf("错误: 解析模板失败: %v\n", err)
+			os.Exit(1)
+		}
+		format = templateFormat(tmpl)
+	}
+
+	if *depthReport || *layersFile != "" || *explain != "" || *leaves || *roots || *findUnreachable || *matrixCSV || *deepThirdParty || deepFormat || *tree {
+		*deep = true
+	}
+
+	if *archive != "" && *since != "" {
+		fmt.Println("错误: -archive 和 -since 不能同时使用")
+		os.Exit(1)
+	}
+
+	if *filePath == "-" && *archive != "" {
+		fmt.Println("错误: -f - 不能与 -archive 同时使用")
+		os.Exit(1)
+	}
+
+	if *branchDiffRange != "" && (*archive != "" || *since != "") {
+		fmt.Println("错误: -branch-diff 不能与 -archive 或 -since 同时使用")
+		os.Exit(1)
+	}
+
+	if *maxDepth < 0 {
+		fmt.Println("错误: -max-depth 不能小于 0")
+		os.Exit(1)
+	}
+
+	if *rebuildIndex && *indexFile == "" {
+		fmt.Println("错误: -rebuild-index 需要同时指定 -index <文件>")
+		os.Exit(1)
+	}
+
+	if *baselineUpdate && *baselineFile == "" {
+		fmt.Println("错误: -baseline-update 需要同时指定 -baseline <文件>")
+		os.Exit(1)
+	}
+
+	// 读取自定义分类规则
+	var classifyRules []classifyRule
+	if *classifyRulesFile != "" {
+		if classifyRules, err = loadClassifyRules(*classifyRulesFile); err != nil {
+			fmt.Printf("错误: 读取分类规则失败: %v\n", err)
+			os.Exit(1)
+		}
+		for _, r := range classifyRules {
+			if r.Category == "extended" && !*splitXTools {
+				fmt.Println("错误: 分类规则使用了 extended 分类，需要同时指定 -split-xtools")
+				os.Exit(1)
+			}
+		}
+	}
 
 	// 验证 filterType
 	validTypes := map[string]bool{
@@ -258,54 +1231,544 @@ func main() {
 		"stdlib":      true,
 		"third-party": true,
 		"internal":    true,
+		"extended":    *splitXTools,
+	}
+	for _, name := range customCategories(classifyRules) {
+		validTypes[name] = true
 	}
 	if !validTypes[*filterType] {
 		fmt.Printf("错误: 无效的类型 '%s'\n", *filterType)
-		fmt.Println("支持的类型: stdlib, third-party, internal, all")
+		fmt.Println("支持的类型: stdlib, third-party, internal, all (使用 -split-xtools 时还支持 extended，-classify-rules 中定义的分类也可使用)")
 		os.Exit(1)
 	}
 
-	// 获取绝对路径
-	absPath, err := filepath.Abs(*filePath)
-	if err != nil {
-		fmt.Printf("错误: 无法获取文件绝对路径: %v\n", err)
+	// 验证 sortBy
+	validSorts := map[string]bool{
+		"name":     true,
+		"count":    true,
+		"category": true,
+	}
+	if !validSorts[*sortBy] {
+		fmt.Printf("错误: 无效的排序方式 '%s'\n", *sortBy)
+		fmt.Println("支持的排序方式: name, count, category")
 		os.Exit(1)
 	}
 
-	// 检查文件是否存在
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		fmt.Printf("错误: 文件不存在: %s\n", absPath)
+	// 验证 colorMode
+	if *colorMode != "auto" && *colorMode != "always" && *colorMode != "never" {
+		fmt.Printf("错误: 无效的颜色模式 '%s'\n", *colorMode)
+		fmt.Println("支持的颜色模式: auto, always, never")
 		os.Exit(1)
 	}
 
-	// 获取项目根目录（假设脚本在 scripts 目录下）
-	projectPath, err := os.Getwd()
+	// 设置输出语言和分类标题
+	if !setLanguage(*lang) {
+		fmt.Printf("错误: 不支持的语言 '%s'\n", *lang)
+		fmt.Println("支持的语言: zh, en")
+		os.Exit(1)
+	}
+	setCategoryLabels(map[string]string{
+		"stdlib":      *labelStdlib,
+		"third-party": *labelThirdParty,
+		"internal":    *labelInternal,
+	})
+
+	var matchRe *regexp.Regexp
+	if *match != "" {
+		if matchRe, err = regexp.Compile(*match); err != nil {
+			fmt.Printf("错误: 无效的正则表达式 -match '%s': %v\n", *match, err)
+			os.Exit(1)
+		}
+	}
+
+	if *maxThirdPartyPct < 0 || *maxThirdPartyPct > 100 {
+		fmt.Println("错误: -max-third-party-pct 必须在 0 到 100 之间")
+		os.Exit(1)
+	}
+
+	if *staleMonths <= 0 {
+		fmt.Println("错误: -stale-months 必须大于 0")
+		os.Exit(1)
+	}
+
+	if *netTimeout <= 0 || *netRetries < 0 {
+		fmt.Println("错误: -timeout 必须大于 0，-retries 不能小于 0")
+		os.Exit(1)
+	}
+
+	if *flagTestImports && len(parseTestImportPatterns(*testImportPatterns)) == 0 {
+		fmt.Println("错误: -test-import-patterns 不能为空")
+		os.Exit(1)
+	}
+
+	forbidden, err := parseForbidStdlib(*forbidStdlib)
 	if err != nil {
-		fmt.Printf("错误: 无法获取当前目录: %v\n", err)
+		fmt.Printf("错误: %v\n", err)
 		os.Exit(1)
 	}
 
-	// 如果当前目录是 scripts，则向上一级
-	if filepath.Base(projectPath) == "scripts" {
-		projectPath = filepath.Dir(projectPath)
+	var platforms []platform
+	if *matrix != "" {
+		if *allFiles {
+			fmt.Println("错误: -matrix 需要按构建约束过滤文件，不能与 -all-files 同时使用")
+			os.Exit(1)
+		}
+		if platforms, err = parsePlatforms(*matrix); err != nil {
+			fmt.Printf("错误: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	fmt.Printf("分析文件: %s\n", absPath)
-	if *deep {
-		fmt.Println("模式: 深度分析（递归内部包）")
-	} else {
-		fmt.Println("模式: 浅层分析（仅直接依赖）")
+	weights, err := parseScoreWeights(*scoreWeights)
+	if err != nil {
+		fmt.Printf("错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 解压源码包，以其中的模块作为项目根目录
+	if *archive != "" {
+		root, tmpDir, err := extractArchive(*archive)
+		if err != nil {
+			fmt.Printf("错误: 解压 %s 失败: %v\n", *archive, err)
+			os.Exit(1)
+		}
+		atExit(func() { os.RemoveAll(tmpDir) })
+		defer runCleanups()
+		projectPath = root
+		// 未指定 -f 时分析整个模块 (见下方的 projectFiles)
+		if *filePath != "" && !filepath.IsAbs(*filePath) {
+			*filePath = filepath.Join(root, *filePath)
+		}
 	}
 
 	// 创建分析器
 	analyzer := NewDependencyAnalyzer(projectPath)
+	analyzer.internalPrefix = *internalPrefix
+	analyzer.alsoInternal = alsoInternal
+	analyzer.splitXTools = *splitXTools
+	analyzer.skipGenerated = *skipGenerated
+	analyzer.allFiles = *allFiles
+	analyzer.setClassifyRules(classifyRules)
+	analyzer.forbidStdlib = forbidden
+	analyzer.allowDeps = parsePatternList(*allowDeps)
+	analyzer.denyDeps = parsePatternList(*denyDeps)
+	analyzer.importOrder = *importOrder
+	analyzer.visibility = *checkVisibility
+	analyzer.deepThirdParty = *deepThirdParty
+	analyzer.maxDepth = *maxDepth
+	analyzer.net = newNetClient(*netTimeout, *netRetries, *offline)
+	if *flagTestImports {
+		analyzer.testHelpers = parseTestImportPatterns(*testImportPatterns)
+	}
+	if *normalize {
+		analyzer.enableNormalize()
+	}
+	analyzer.trace = *trace
+	analyzer.showProgress = !*quiet
+	analyzer.stream = *stream && !*listFiles && !*downloadList && !*matrixCSV && !*compact && !*tui && format.Write == nil
+	analyzer.ndjson = format.Name == "ndjson"
+	if *layersFile != "" {
+		rules, err := loadLayerRules(*layersFile)
+		if err != nil {
+			fatalf("读取分层规则失败: %v", err)
+		}
+		analyzer.layers = rules
+	}
+	for _, pattern := range strings.Split(*excludes, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			analyzer.excludes = append(analyzer.excludes, pattern)
+		}
+	}
+
+	// 输出到文件 (多个格式时 -o 为各格式文件的前缀)
+	if *outFile != "" && !multiFormat {
+		if err := redirectOutput(*outFile); err != nil {
+			fatalf("无法创建输出文件: %v", err)
+		}
+	}
+
+	// 合并多个 JSON 报告
+	if *merge {
+		if flag.NArg() == 0 {
+			fatalf("-merge 需要至少一个 JSON 报告文件")
+		}
+		merged, err := mergeReports(flag.Args())
+		if err != nil {
+			fatalf("合并报告失败: %v", err)
+		}
+		data, err := marshalJSON(merged)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		os.Stdout.Write(data)
+		finish(0)
+		return
+	}
+
+	// 只分析 git 改动引入的依赖
+	if *since != "" {
+		introduced, changed, err := analyzer.analyzeSince(*since)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		printIntroduced(*since, changed, introduced)
+		code := 0
+		if len(introduced) > 0 {
+			code = 1
+		}
+		finish(code)
+		return
+	}
+
+	// 对比两个 git 引用之间的第三方模块
+	if *branchDiffRange != "" {
+		base, head, err := parseBranchRange(*branchDiffRange)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		diff, err := analyzer.analyzeBranchDiff(base, head)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		printBranchDiff(diff)
+		finish(0)
+		return
+	}
+
+	// 解析入口文件（支持通配符）或包导入路径
+	var entries []string
+	if *pkgPath != "" {
+		entries, err = analyzer.resolvePackage(*pkgPath)
+	} else if *filePath == "-" {
+		var entry string
+		entry, err = analyzer.readStdin(*stdinName)
+		entries = []string{entry}
+	} else if *filePath == "" {
+		// -archive 未指定 -f: 模块内的所有包，不含 vendor、testdata 和嵌套模块
+		entries, err = analyzer.projectFiles()
+	} else {
+		entries, err = analyzer.resolveEntries(*filePath)
+	}
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	analyzer.useEntryModules(entries)
+
+	if !*quiet && !*downloadList && !*listFiles && !*matrixCSV && !*compact && format.Write == nil {
+		analyzer.printPreamble(*pkgPath, entries, *deep)
+	}
+
+	// 按平台矩阵分析
+	if *matrix != "" {
+		result, err := analyzer.analyzeMatrix(entries, *deep, platforms)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		printMatrix(result, printOptions{quiet: *quiet})
+		finish(0)
+		return
+	}
+
+	// 读取导入索引
+	if *indexFile != "" {
+		index, err := loadIndex(*indexFile, *rebuildIndex)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "警告: %v，将重建索引\n", err)
+			index, _ = loadIndex(*indexFile, true)
+		}
+		analyzer.index = index
+	}
 
 	// 分析依赖
-	if err := analyzer.analyzeDependencies(absPath, *deep); err != nil {
-		fmt.Printf("错误: %v\n", err)
-		os.Exit(1)
+	if err := analyzer.analyzeDependencies(entries, *deep); err != nil {
+		fatalf("%v", err)
+	}
+
+	// 更新导入索引
+	if analyzer.index != nil {
+		if err := analyzer.saveIndex(*indexFile); err != nil {
+			fatalf("写入索引失败: %v", err)
+		}
+		if *verbose && !*quiet {
+			fmt.Printf("索引: %d 个文件未改动，%d 个文件重新解析\n", analyzer.index.hits, analyzer.index.misses)
+		}
+	}
+	if matchRe != nil {
+		analyzer.keepMatching(matchRe)
+	}
+
+	// 只列出解析的文件
+	if *listFiles {
+		analyzer.printParsedFiles(*verbose)
+		finish(0)
+		return
+	}
+
+	// 只输出模块下载列表
+	if *downloadList {
+		analyzer.printDownloadList()
+		finish(0)
+		return
+	}
+
+	// 只输出一行摘要
+	if *compact {
+		fmt.Println(analyzer.compactSummary(*filterType))
+		finish(0)
+		return
+	}
+
+	// 只输出邻接矩阵
+	if *matrixCSV {
+		if err := analyzer.writeAdjacencyCSV(entries); err != nil {
+			fatalf("写入邻接矩阵失败: %v", err)
+		}
+		finish(0)
+		return
+	}
+
+	// 交互式浏览
+	if *tui {
+		if err := analyzer.runTUI(); err != nil {
+			fatalf("%v", err)
+		}
+		finish(0)
+		return
+	}
+
+	// 读取包文档摘要
+	if *synopsis {
+		analyzer.loadSynopses(*verbose)
 	}
 
 	// 打印结果
-	analyzer.printResults(*verbose, *filterType)
+	opts := printOptions{
+		verbose:    *verbose,
+		deep:       *deep,
+		filterType: *filterType,
+		sortBy:     *sortBy,
+		color:      useColor(*colorMode),
+		quiet:      *quiet,
+		topN:       *topN,
+		statsOnly:  analyzer.stream,
+	}
+
+	// GitHub Actions 的工作流命令和步骤摘要
+	if *gha {
+		if err := analyzer.writeGitHubActions(entries, opts); err != nil {
+			fatalf("写入 GitHub Actions 步骤摘要失败: %v", err)
+		}
+	}
+
+	// 决定退出码的检查，结构化输出时同样生效
+	gates := gateOptions{
+		strict:       *strict,
+		checkMissing: *checkMissing,
+		limits: thresholds{
+			maxThirdParty:    *maxThirdParty,
+			maxTotal:         *maxTotal,
+			maxThirdPartyPct: *maxThirdPartyPct,
+		},
+		baselineFile:   *baselineFile,
+		baselineUpdate: *baselineUpdate,
+		quiet:          *quiet,
+	}
+
+	// 以结构化格式输出
+	if format.Write != nil {
+		if multiFormat {
+			files, err := analyzer.writeFormatFiles(*outFile, formats, entries, opts)
+			if err != nil {
+				fatalf("%v", err)
+			}
+			if !*quiet {
+				fmt.Printf("已写入: %s\n", strings.Join(files, ", "))
+			}
+		} else if err := format.Write(analyzer, os.Stdout, entries, opts); err != nil {
+			fatalf("生成 %s 输出失败: %v", format.Name, err)
+		}
+		if *splitOutput != "" {
+			if _, err := analyzer.writeSplitOutput(*splitOutput, format); err != nil {
+				fatalf("写入分类文件失败: %v", err)
+			}
+		}
+		// 检查结果已包含在输出中 (如 sarif、junit) 或不适合混入结构化输出，只用于决定退出码
+		failed, err := analyzer.runGates(gates, false)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		code := 0
+		if failed {
+			code = 1
+		}
+		finish(code)
+		return
+	}
+
+	if *byEntry {
+		if err := analyzer.printByEntry(entries, *deep, matchRe, opts); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	analyzer.printResults(opts)
+
+	// 打印跳过的生成文件
+	if *verbose && len(analyzer.skippedGenerated) > 0 {
+		analyzer.printSkippedGenerated()
+	}
+
+	// 打印标准库分组
+	if *groupStd && (*filterType == "all" || *filterType == "stdlib") {
+		analyzer.printStdlibGroups(opts)
+	}
+
+	// 打印实际使用的模块
+	if *requiredModules {
+		analyzer.printRequiredModules(opts)
+	}
+
+	// 打印功能重叠的依赖
+	if *findOverlap {
+		analyzer.printOverlaps(opts)
+	}
+
+	// 打印模块代码量估算
+	if *sizeEstimate {
+		analyzer.printSizeEstimate(opts)
+	}
+
+	// 打印依赖版本时间
+	if *versionSummary {
+		if analyzer.goMod == nil {
+			fatalf("未找到 go.mod: %s", filepath.Join(projectPath, "go.mod"))
+		}
+		analyzer.printVersionSummary(*staleMonths, opts)
+	}
+
+	// 打印依赖引入者
+	if *blame {
+		blames, err := analyzer.blameDependencies()
+		if err != nil {
+			fatalf("%v", err)
+		}
+		analyzer.printBlame(blames, opts)
+	}
+
+	// 打印健康度评分
+	if *showScore {
+		analyzer.printScore(analyzer.computeScore(weights), opts)
+	}
+
+	// 打印包的文件级流行度
+	if *popularity {
+		analyzer.printPopularity(opts)
+	}
+
+	// 按文件打印依赖
+	if *perFile {
+		analyzer.printPerFile(opts)
+	}
+
+	// 打印内部包层级
+	if *depthReport {
+		analyzer.printDepthReport(opts)
+	}
+
+	// 打印导入树
+	if *tree {
+		analyzer.printImportTree(entries, opts)
+	}
+
+	// 打印叶子包和根包
+	if *leaves {
+		printPackageList("叶子内部包", analyzer.leafPackages(entries), opts)
+	}
+	if *roots {
+		printPackageList("根内部包", analyzer.rootPackages(entries), opts)
+	}
+
+	// 打印无法到达的内部包
+	if *findUnreachable {
+		unreachable, err := analyzer.findUnreachable(entries)
+		if err != nil {
+			fatalf("枚举项目内的包失败: %v", err)
+		}
+		analyzer.printUnreachable(unreachable, opts)
+	}
+
+	// 打印导入链
+	if *explain != "" {
+		analyzer.printExplain(entries, *explain, opts)
+	}
+
+	// 输出 SARIF 报告
+	if *sarifFile != "" {
+		if err := analyzer.writeSarif(*sarifFile); err != nil {
+			fatalf("写入 SARIF 报告失败: %v", err)
+		}
+		if !*quiet {
+			fmt.Printf("\nSARIF 报告已写入: %s\n", *sarifFile)
+		}
+	}
+
+	// 按分类写入文件
+	if *splitOutput != "" {
+		files, err := analyzer.writeSplitOutput(*splitOutput, format)
+		if err != nil {
+			fatalf("写入分类文件失败: %v", err)
+		}
+		if !*quiet {
+			fmt.Printf("\n分类列表已写入: %s\n", strings.Join(files, ", "))
+		}
+	}
+
+	// 生成依赖徽章
+	if *badgeFile != "" {
+		if err := analyzer.writeBadge(*badgeFile, weights); err != nil {
+			fatalf("写入徽章失败: %v", err)
+		}
+		if !*quiet {
+			fmt.Printf("\n徽章已写入: %s\n", *badgeFile)
+		}
+	}
+
+	// 列出显式忽略的导入
+	if len(analyzer.ignored) > 0 {
+		analyzer.printIgnored()
+	}
+
+	// 报告重复导入
+	if len(analyzer.duplicates) > 0 {
+		analyzer.printDuplicates()
+	}
+
+	// 报告疑似 fork 的模块
+	if forks := analyzer.findForkCandidates(); len(forks) > 0 {
+		printForkCandidates(forks)
+	}
+
+	// 报告弃用的标准库
+	if deprecated := analyzer.findDeprecated(); len(deprecated) > 0 {
+		analyzer.printDeprecated(deprecated, *verbose)
+	}
+
+	// 查找可移除的 indirect 依赖
+	if *findRemovable {
+		if analyzer.goMod == nil {
+			fatalf("未找到 go.mod: %s", filepath.Join(projectPath, "go.mod"))
+		}
+		printRemovableRequires(analyzer.findRemovableRequires())
+	}
+
+	failed, err := analyzer.runGates(gates, true)
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	code := 0
+	if failed {
+		code = 1
+	}
+	finish(code)
 }
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/geekeryy/scripts/cmd/check_deps/deps"
+)
+
+// 在临时目录中创建模块 example.com/m，files 为相对路径 (使用 /) 到文件内容的映射，返回模块根目录
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	files["go.mod"] = "module example.com/m\n\ngo 1.22\n"
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// 从入口文件开始深度分析模块
+func analyzeModule(t *testing.T, root, entry string) *DependencyAnalyzer {
+	t.Helper()
+	da := NewDependencyAnalyzer(root)
+	if err := da.analyzeDependencies([]string{filepath.Join(root, filepath.FromSlash(entry))}, true); err != nil {
+		t.Fatal(err)
+	}
+	return da
+}
+
+// 通过符号链接和真实路径导入同一个包目录时，每个文件只解析一次，也不会因为链接形成死循环
+func TestSymlinkedPackageParsedOnce(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main.go": "package main\n\nimport (\n\t_ \"example.com/m/link\"\n\t_ \"example.com/m/real\"\n)\n",
+		// 包通过符号链接导入自身
+		"real/real.go": "package real\n\nimport (\n\t_ \"example.com/m/link\"\n\t_ \"strings\"\n)\n",
+	})
+	if err := os.Symlink(filepath.Join(root, "real"), filepath.Join(root, "link")); err != nil {
+		t.Skipf("无法创建符号链接: %v", err)
+	}
+
+	da := analyzeModule(t, root, "main.go")
+
+	parsed := make(map[string]int)
+	for _, file := range da.parsedFiles {
+		parsed[realPath(file)]++
+	}
+	for file, n := range parsed {
+		if n != 1 {
+			t.Errorf("%s 被解析了 %d 次，应只解析一次", file, n)
+		}
+	}
+	if want := realPath(filepath.Join(root, "real", "real.go")); parsed[want] != 1 {
+		t.Errorf("real/real.go 应被解析一次，实际解析的文件: %v", da.parsedFiles)
+	}
+	if !da.stdlib["strings"] {
+		t.Errorf("应通过符号链接目录中的文件找到 strings，标准库: %v", sortedKeys(da.stdlib))
+	}
+}
+
+// 外部测试包导入被测包本身时不计入依赖，同目录下的子包照常计入
+func TestSelfImportSkipped(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"svc/a/a.go":       "package a\n\nimport _ \"example.com/m/svc/a/sub\"\n",
+		"svc/a/a_test.go":  "package a_test\n\nimport (\n\t_ \"example.com/m/svc/a\"\n\t_ \"example.com/m/svc/a/sub\"\n)\n",
+		"svc/a/sub/sub.go": "package sub\n",
+	})
+
+	da := analyzeModule(t, root, "svc/a/a_test.go")
+
+	if !da.internal["example.com/m/svc/a/sub"] {
+		t.Errorf("应计入子包 example.com/m/svc/a/sub，内部包: %v", sortedKeys(da.internal))
+	}
+	if da.internal["example.com/m/svc/a"] {
+		t.Errorf("不应计入所在包自身 example.com/m/svc/a，内部包: %v", sortedKeys(da.internal))
+	}
+}
+
+// 汇编文件不参与分析，import "C" 归入 cgo 而不是标准库或第三方库
+func TestAssemblyAndCgo(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main.go":         "package main\n\nimport (\n\t_ \"example.com/m/asm\"\n\t_ \"example.com/m/cg\"\n)\n",
+		"asm/add.go":      "package asm\n\nfunc Add(a, b int) int\n",
+		"asm/add_amd64.s": "#include \"textflag.h\"\n\nTEXT ·Add(SB),NOSPLIT,$0-24\n\tRET\n",
+		"cg/cg.go":        "package cg\n\n// #include <stdio.h>\nimport \"C\"\n\nimport _ \"strings\"\n",
+	})
+
+	want := []string{filepath.Join(root, "asm", "add.go")}
+	if got := goFilesInDir(filepath.Join(root, "asm"), true); !reflect.DeepEqual(got, want) {
+		t.Errorf("goFilesInDir(asm) = %v, want %v", got, want)
+	}
+
+	da := analyzeModule(t, root, "main.go")
+
+	if !da.cgo["C"] {
+		t.Errorf("import \"C\" 应归入 cgo")
+	}
+	if got := da.category("C"); got != "cgo" {
+		t.Errorf("category(\"C\") = %q, want \"cgo\"", got)
+	}
+	if da.stdlib["C"] || da.thirdParty["C"] || da.internal["C"] {
+		t.Errorf("C 不应出现在标准库、第三方库或内部包中")
+	}
+	if !da.stdlib["strings"] {
+		t.Errorf("应找到 cg 包导入的 strings，标准库: %v", sortedKeys(da.stdlib))
+	}
+}
+
+// 允许列表和禁止列表只约束外部依赖，禁止列表优先
+func TestDisallowedDeps(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main.go":    "package main\n\nimport (\n\t_ \"fmt\"\n\n\t_ \"github.com/acme/kit/log\"\n\t_ \"github.com/acme/legacy\"\n\t_ \"github.com/other/lib\"\n\n\t_ \"example.com/m/svc\"\n)\n",
+		"svc/svc.go": "package svc\n",
+	})
+	da := analyzeModule(t, root, "main.go")
+	da.allowDeps = []string{"github.com/acme"}
+	da.denyDeps = []string{"github.com/acme/legacy"}
+
+	got := make(map[string]string)
+	for _, d := range da.findDisallowedDeps() {
+		got[d.Pkg] = d.Reason
+	}
+	want := map[string]string{
+		"github.com/acme/legacy": "匹配禁止列表中的 github.com/acme/legacy",
+		"github.com/other/lib":   "不在允许列表中",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findDisallowedDeps() = %v, want %v", got, want)
+	}
+}
+
+// 嵌套模块的查找跳过 vendor、node_modules 和隐藏目录，同一项目的分析器共用查找结果
+func TestNestedModules(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"tools/go.mod":              "module example.com/m/tools\n",
+		"vendor/x/go.mod":           "module example.com/x\n",
+		"web/node_modules/y/go.mod": "module example.com/y\n",
+		".cache/z/go.mod":           "module example.com/z\n",
+	})
+	da := NewDependencyAnalyzer(root)
+	var paths []string
+	for _, m := range da.localModules {
+		paths = append(paths, m.Path)
+	}
+	if want := []string{"example.com/m", "example.com/m/tools"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("本地模块 = %v, want %v", paths, want)
+	}
+
+	// 之后新增的嵌套模块不会被 withConfig 重新遍历发现
+	if err := os.MkdirAll(filepath.Join(root, "extra"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "extra", "go.mod"), []byte("module example.com/m/extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if n := da.withConfig(da.projectPath); !reflect.DeepEqual(n.localModules, da.localModules) {
+		t.Errorf("withConfig 应沿用已找到的本地模块: %v", n.localModules)
+	}
+}
+
+// 深度分析是否进入一个包取决于最终分类，自定义规则可以将包归入或移出内部包
+func TestClassifyRulesControlRecursion(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main.go":    "package main\n\nimport (\n\t_ \"corp.io/ext\"\n\n\t_ \"example.com/m/gen\"\n\t_ \"example.com/m/lib\"\n\t_ \"example.com/m/svc\"\n)\n",
+		"gen/gen.go": "package gen\n\nimport _ \"strings\"\n",
+		"lib/lib.go": "package lib\n\nimport _ \"bytes\"\n",
+		"svc/svc.go": "package svc\n\nimport _ \"fmt\"\n",
+	})
+	da := NewDependencyAnalyzer(root)
+	da.internalPrefix = "example.com/m/svc"
+	da.setClassifyRules([]classifyRule{
+		{Pattern: "example.com/m/gen", Category: "generated"},
+		{Pattern: "example.com/m/lib", Category: "internal"},
+		{Pattern: "corp.io/ext", Category: "internal"},
+	})
+	if err := da.analyzeDependencies([]string{filepath.Join(root, "main.go")}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if !da.stdlib["fmt"] {
+		t.Errorf("应进入按 -internal-prefix 判断的内部包 svc")
+	}
+	if !da.stdlib["bytes"] {
+		t.Errorf("应进入按规则归为内部包的 lib")
+	}
+	if da.stdlib["strings"] {
+		t.Errorf("不应进入按规则归为 generated 的 gen")
+	}
+	if da.unresolved["corp.io/ext"] {
+		t.Errorf("按规则归为内部包但本地没有源码的包不应报告为无法解析")
+	}
+}
+
+// 目录中没有 .go 文件的包不是 package main，也不会导致越界
+func TestIsMainPackage(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"cmd/tool/main.go": "package main\n",
+		"lib/lib.go":       "package lib\n",
+		"docs/README.md":   "# docs\n",
+	})
+	da := NewDependencyAnalyzer(root)
+	tests := map[string]bool{
+		"example.com/m/cmd/tool": true,
+		"example.com/m/lib":      false,
+		"example.com/m/docs":     false,
+		"example.com/m/missing":  false,
+	}
+	for pkg, want := range tests {
+		if got := da.isMainPackage(pkg); got != want {
+			t.Errorf("isMainPackage(%q) = %v, want %v", pkg, got, want)
+		}
+	}
+}
+
+// 分析整个模块时的入口跳过 vendor、testdata、隐藏目录和嵌套模块
+func TestProjectFiles(t *testing.T) {
+	root := writeModule(t, map[string]string{
+		"main.go":                    "package main\n",
+		"svc/svc.go":                 "package svc\n",
+		"svc/svc_test.go":            "package svc\n",
+		"svc/testdata/bad.go":        "not go\n",
+		"vendor/github.com/x/y/y.go": "package y\n\nimport _ \"github.com/evil/dep\"\n",
+		".git/hooks/h.go":            "package hooks\n",
+		"_old/old.go":                "package old\n",
+		"tools/go.mod":               "module example.com/m/tools\n",
+		"tools/tool.go":              "package tools\n",
+	})
+	da := NewDependencyAnalyzer(root)
+	got, err := da.projectFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "main.go"), filepath.Join(root, "svc", "svc.go")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("projectFiles() = %v, want %v", got, want)
+	}
+}
+
+// 模块路径按路径段匹配: 只有前缀相同的其他模块是第三方库，与 deps.AnalyzeImports 的分类一致
+func TestSiblingPrefixIsThirdParty(t *testing.T) {
+	imports := []string{"example.com/m/svc", "example.com/mx/x", "example.com/m-tools/y"}
+	root := writeModule(t, map[string]string{
+		"main.go":    "package main\n\nimport (\n\t_ \"example.com/m-tools/y\"\n\t_ \"example.com/m/svc\"\n\t_ \"example.com/mx/x\"\n)\n",
+		"svc/svc.go": "package svc\n",
+	})
+
+	for _, prefix := range []string{"", "example.com/m"} {
+		da := NewDependencyAnalyzer(root)
+		da.internalPrefix = prefix
+		if err := da.analyzeDependencies([]string{filepath.Join(root, "main.go")}, true); err != nil {
+			t.Fatal(err)
+		}
+		want := deps.AnalyzeImports(imports, "example.com/m")
+		if got := sortedKeys(da.internal); !reflect.DeepEqual(got, want.Internal) {
+			t.Errorf("internal-prefix %q: 内部包 = %v, want %v", prefix, got, want.Internal)
+		}
+		if got := sortedKeys(da.thirdParty); !reflect.DeepEqual(got, want.ThirdParty) {
+			t.Errorf("internal-prefix %q: 第三方库 = %v, want %v", prefix, got, want.ThirdParty)
+		}
+		if len(da.unresolved) > 0 {
+			t.Errorf("internal-prefix %q: 不应有无法解析的内部包: %v", prefix, sortedKeys(da.unresolved))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 转义 Markdown 表格单元格中的 |
+func mdCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// 以 Markdown 输出报告: 摘要 (入口和统计) 加上每个分类一张表格，适合贴到 PR 描述或由 CI 机器人发布
+func (da *DependencyAnalyzer) writeMarkdown(w io.Writer, entries []string, opts printOptions) error {
+	view := da.buildView(entries, opts)
+
+	fmt.Fprintln(w, "# 依赖分析报告")
+	fmt.Fprintln(w)
+	for _, entry := range view.Entries {
+		fmt.Fprintf(w, "- 入口: `%s`\n", entry)
+	}
+	if view.Deep {
+		fmt.Fprintln(w, "- 模式: 深度分析")
+	} else {
+		fmt.Fprintln(w, "- 模式: 浅层分析 (仅直接依赖)")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| 分类 | 包数量 |")
+	fmt.Fprintln(w, "| --- | ---: |")
+	for _, cat := range da.categories() {
+		fmt.Fprintf(w, "| %s | %d |\n", mdCell(cat.Title), len(da.categorySet(cat.Key)))
+	}
+	fmt.Fprintf(w, "| **总计** | **%d** |\n", view.Report.Stats.Total)
+
+	for _, cat := range view.Categories {
+		fmt.Fprintf(w, "\n## %s (%d)\n\n", cat.Title, len(cat.Packages))
+		fmt.Fprintln(w, "| 包 | 模块 | 导入次数 | 直接导入 |")
+		fmt.Fprintln(w, "| --- | --- | ---: | :---: |")
+		for _, p := range cat.Packages {
+			module, direct := "", ""
+			if p.Module != "" {
+				module = "`" + mdCell(p.Module) + "`"
+			}
+			if p.Direct {
+				direct = "✓"
+			}
+			fmt.Fprintf(w, "| `%s` | %s | %d | %s |\n", mdCell(p.Path), module, p.Count, direct)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"go/build"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// -matrix 的默认目标平台
+const defaultMatrix = "linux/amd64,windows/amd64,darwin/arm64"
+
+// 目标平台
+type platform struct {
+	GOOS, GOARCH string
+}
+
+func (p platform) String() string {
+	return p.GOOS + "/" + p.GOARCH
+}
+
+// 解析逗号分隔的 GOOS/GOARCH 列表
+func parsePlatforms(s string) ([]platform, error) {
+	var platforms []platform
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		goos, goarch, ok := strings.Cut(item, "/")
+		if !ok || goos == "" || goarch == "" {
+			return nil, fmt.Errorf("无效的平台 '%s'，格式应为 GOOS/GOARCH", item)
+		}
+		platforms = append(platforms, platform{GOOS: goos, GOARCH: goarch})
+	}
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("-matrix 至少需要一个平台")
+	}
+	return platforms, nil
+}
+
+// 目标平台的构建上下文，交叉编译时与 go build 一样默认关闭 cgo
+func buildContext(p platform) *build.Context {
+	ctx := build.Default
+	ctx.GOOS, ctx.GOARCH = p.GOOS, p.GOARCH
+	ctx.CgoEnabled = ctx.CgoEnabled && p.GOOS == runtime.GOOS && p.GOARCH == runtime.GOARCH
+	return &ctx
+}
+
+// 按构建约束 (文件名后缀和 //go:build) 过滤文件，未设置构建上下文或 -all-files 时不过滤
+func (da *DependencyAnalyzer) buildFiles(files []string) []string {
+	if da.buildCtx == nil || da.allFiles {
+		return files
+	}
+	var matched []string
+	for _, file := range files {
+		if ok, err := da.buildCtx.MatchFile(filepath.Dir(file), filepath.Base(file)); err == nil && ok {
+			matched = append(matched, file)
+		}
+	}
+	return matched
+}
+
+// 以相同的配置创建针对指定平台的分析器
+func (da *DependencyAnalyzer) forPlatform(p platform) *DependencyAnalyzer {
+	n := da.withConfig(da.projectPath)
+	n.buildCtx = buildContext(p)
+	return n
+}
+
+// 各平台的分析结果: 所有平台共有的外部依赖，以及只在部分平台出现的外部依赖 (包 -> 平台)
+type matrixResult struct {
+	Platforms []platform
+	Common    []string
+	Specific  map[string][]string
+}
+
+// 分别按每个平台的构建约束分析，对比外部依赖 (第三方库、扩展库及自定义分类中的外部包)
+func (da *DependencyAnalyzer) analyzeMatrix(entries []string, deep bool, platforms []platform) (*matrixResult, error) {
+	seen := make(map[string][]string)
+	for _, p := range platforms {
+		n := da.forPlatform(p)
+		if files := n.buildFiles(entries); len(files) > 0 {
+			if err := n.analyzeDependencies(files, deep); err != nil {
+				return nil, fmt.Errorf("%s: %v", p, err)
+			}
+		}
+		for _, pkg := range n.externalPackages() {
+			seen[pkg] = append(seen[pkg], p.String())
+		}
+	}
+
+	result := &matrixResult{Platforms: platforms, Specific: make(map[string][]string)}
+	for pkg, list := range seen {
+		if len(list) == len(platforms) {
+			result.Common = append(result.Common, pkg)
+		} else {
+			result.Specific[pkg] = list
+		}
+	}
+	sort.Strings(result.Common)
+	return result, nil
+}
+
+// 打印平台矩阵分析结果
+func printMatrix(r *matrixResult, opts printOptions) {
+	names := make([]string, len(r.Platforms))
+	for i, p := range r.Platforms {
+		names[i] = p.String()
+	}
+	printSectionHeader("平台依赖矩阵: "+strings.Join(names, ", "), opts.quiet)
+	fmt.Printf("所有平台共有的外部依赖 (%d):\n", len(r.Common))
+	for _, pkg := range r.Common {
+		fmt.Printf("  %s\n", pkg)
+	}
+	specific := make([]string, 0, len(r.Specific))
+	for pkg := range r.Specific {
+		specific = append(specific, pkg)
+	}
+	sort.Strings(specific)
+	fmt.Printf("\n平台相关的外部依赖 (%d):\n", len(specific))
+	for _, pkg := range specific {
+		fmt.Printf("  %-50s %s\n", pkg, strings.Join(r.Specific[pkg], ", "))
+	}
+	printSectionFooter(opts.quiet)
+}
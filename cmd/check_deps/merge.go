@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// 合并多个服务的 JSON 报告得到的汇总报告
+type MergedReport struct {
+	Report
+	// 每个第三方库 (含扩展库) 被哪些服务使用，服务名见 serviceNames
+	Services map[string][]string `json:"services"`
+}
+
+// 报告文件对应的服务名: 取文件名 (去掉扩展名)，多个报告文件名相同时
+// (如 svc-a/deps.json 和 svc-b/deps.json) 改用所在目录名，仍然重复时报错
+func serviceNames(files []string) ([]string, error) {
+	base := func(file string) string {
+		return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	count := make(map[string]int)
+	for _, file := range files {
+		count[base(file)]++
+	}
+	names := make([]string, len(files))
+	owner := make(map[string]string)
+	for i, file := range files {
+		name := base(file)
+		if count[name] > 1 {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return nil, err
+			}
+			name = filepath.Base(filepath.Dir(abs))
+		}
+		if prev, ok := owner[name]; ok {
+			return nil, fmt.Errorf("%s 和 %s 对应同一个服务名 %q，请重命名报告文件", prev, file, name)
+		}
+		owner[name] = file
+		names[i] = name
+	}
+	return names, nil
+}
+
+// 合并多个报告: 各分类取并集并重新计算统计信息
+func mergeReports(files []string) (*MergedReport, error) {
+	names, err := serviceNames(files)
+	if err != nil {
+		return nil, err
+	}
+	stdlib := make(map[string]bool)
+	extended := make(map[string]bool)
+	thirdParty := make(map[string]bool)
+	internal := make(map[string]bool)
+	custom := make(map[string]map[string]bool)
+	services := make(map[string]map[string]bool)
+	cgo := false
+	addAll := func(set map[string]bool, pkgs []string) {
+		for _, pkg := range pkgs {
+			set[pkg] = true
+		}
+	}
+
+	for i, file := range files {
+		r, err := loadReport(file)
+		if err != nil {
+			return nil, err
+		}
+		service := names[i]
+		addAll(stdlib, r.Stdlib)
+		addAll(extended, r.Extended)
+		addAll(thirdParty, r.ThirdParty)
+		addAll(internal, r.Internal)
+		for name, pkgs := range r.Custom {
+			if custom[name] == nil {
+				custom[name] = make(map[string]bool)
+			}
+			addAll(custom[name], pkgs)
+		}
+		cgo = cgo || r.Cgo
+		for _, pkg := range append(append([]string{}, r.ThirdParty...), r.Extended...) {
+			if services[pkg] == nil {
+				services[pkg] = make(map[string]bool)
+			}
+			services[pkg][service] = true
+		}
+	}
+
+	merged := &MergedReport{
+		Report: Report{
+			SchemaVersion: reportSchemaVersion,
+			Stdlib:        sortedKeys(stdlib),
+			Extended:      sortedKeys(extended),
+			ThirdParty:    sortedKeys(thirdParty),
+			Internal:      sortedKeys(internal),
+			Cgo:           cgo,
+		},
+		Services: make(map[string][]string, len(services)),
+	}
+	for name, set := range custom {
+		if merged.Custom == nil {
+			merged.Custom = make(map[string][]string)
+		}
+		merged.Custom[name] = sortedKeys(set)
+	}
+	merged.computeStats()
+	for pkg, set := range services {
+		merged.Services[pkg] = sortedKeys(set)
+	}
+	return merged, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServiceNames(t *testing.T) {
+	tests := []struct {
+		files   []string
+		want    []string
+		wantErr bool
+	}{
+		{files: []string{"reports/api.json", "reports/worker.json"}, want: []string{"api", "worker"}},
+		// 文件名相同时取所在目录名
+		{files: []string{"svc-a/deps.json", "svc-b/deps.json", "reports/web.json"}, want: []string{"svc-a", "svc-b", "web"}},
+		// 目录名仍然重复
+		{files: []string{"a/svc/deps.json", "b/svc/deps.json"}, wantErr: true},
+		// 目录名与其他报告的文件名相同
+		{files: []string{"api/deps.json", "web/deps.json", "reports/api.json"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := serviceNames(tt.files)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("serviceNames(%v) error = %v, wantErr %v", tt.files, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("serviceNames(%v) = %v, want %v", tt.files, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 以 Mermaid graph TD 输出内部包之间的导入关系，节点显示相对模块路径的包路径
+func (da *DependencyAnalyzer) writeMermaid(w io.Writer, entries []string, opts printOptions) error {
+	nodes, graph := da.internalGraph(entries)
+	ids := make(map[string]string, len(nodes))
+	fmt.Fprintln(w, "graph TD")
+	for i, pkg := range nodes {
+		ids[pkg] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[pkg], mermaidLabel(da.shortPkg(pkg)))
+	}
+	for _, from := range nodes {
+		for _, to := range sortedKeys(graph[from]) {
+			fmt.Fprintf(w, "  %s --> %s\n", ids[from], ids[to])
+		}
+	}
+	for _, pkg := range da.entryPkgs(entries) {
+		if id, ok := ids[pkg]; ok {
+			fmt.Fprintf(w, "  style %s stroke-width:3px\n", id)
+		}
+	}
+	return nil
+}
+
+// 内部包相对所属模块的路径，模块根包保留完整的模块路径
+func (da *DependencyAnalyzer) shortPkg(pkg string) string {
+	if m, ok := da.moduleForPkg(pkg); ok && pkg != m.Path && m.Path == da.goModPath {
+		return strings.TrimPrefix(pkg, m.Path+"/")
+	}
+	return pkg
+}
+
+// 转义 Mermaid 节点文字中的引号
+func mermaidLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
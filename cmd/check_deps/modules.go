@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 主版本后缀，如 v2、v3
+var majorVersionRe = regexp.MustCompile(`^v[0-9]+$`)
+
+// 根据托管平台的路径规则推测包所属的模块路径
+func guessModulePath(pkg string) string {
+	parts := strings.Split(pkg, "/")
+	n := 3
+	switch parts[0] {
+	case "gopkg.in", "google.golang.org", "go.uber.org", "k8s.io", "go.opentelemetry.io":
+		n = 2
+	}
+	if len(parts) <= n {
+		return pkg
+	}
+	// 带主版本后缀的模块路径，如 github.com/foo/bar/v2
+	if majorVersionRe.MatchString(parts[n]) {
+		n++
+	}
+	return strings.Join(parts[:n], "/")
+}
+
+// 获取第三方包所属的模块和版本，go.mod 中没有声明时按路径规则推测且版本为空
+func (da *DependencyAnalyzer) moduleOfPkg(pkg string) (string, string) {
+	if da.goMod != nil {
+		if req, ok := da.goMod.moduleOf(pkg); ok {
+			return req.Path, req.Version
+		}
+	}
+	return guessModulePath(pkg), ""
+}
+
+// 第三方库 (含扩展库) 所属的模块
+type moduleUsage struct {
+	Path     string
+	Version  string
+	Packages []string
+}
+
+// 汇总实际导入的第三方模块
+func (da *DependencyAnalyzer) usedModules(sortBy string) []moduleUsage {
+	byPath := make(map[string]*moduleUsage)
+	for _, pkg := range da.externalPackages() {
+		path, version := da.moduleOfPkg(pkg)
+		m, ok := byPath[path]
+		if !ok {
+			m = &moduleUsage{Path: path, Version: version}
+			byPath[path] = m
+		}
+		m.Packages = append(m.Packages, pkg)
+	}
+
+	modules := make([]moduleUsage, 0, len(byPath))
+	for _, m := range byPath {
+		sort.Strings(m.Packages)
+		modules = append(modules, *m)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if sortBy == "count" && len(modules[i].Packages) != len(modules[j].Packages) {
+			return len(modules[i].Packages) > len(modules[j].Packages)
+		}
+		return modules[i].Path < modules[j].Path
+	})
+	return modules
+}
+
+// 以 require 块的格式打印实际导入的模块，并列出 go.mod 中声明但未被导入的模块
+func (da *DependencyAnalyzer) printRequiredModules(opts printOptions) {
+	modules := da.usedModules(opts.sortBy)
+	used := make(map[string]bool, len(modules))
+
+	printSectionHeader("实际使用的模块", opts.quiet)
+	fmt.Println("require (")
+	for _, m := range modules {
+		used[m.Path] = true
+		switch {
+		case m.Version == "":
+			fmt.Printf("\t%s // 未在 go.mod 中 require\n", m.Path)
+		case opts.verbose:
+			fmt.Printf("\t%s %s // %d 个包\n", m.Path, m.Version, len(m.Packages))
+		default:
+			fmt.Printf("\t%s %s\n", m.Path, m.Version)
+		}
+	}
+	fmt.Println(")")
+
+	if da.goMod != nil {
+		var unused []moduleRequire
+		for _, req := range da.goMod.Requires {
+			if !used[req.Path] {
+				unused = append(unused, req)
+			}
+		}
+		if len(unused) > 0 {
+			fmt.Printf("\ngo.mod 中声明但未被导入的模块 (%d):\n", len(unused))
+			for _, req := range unused {
+				if req.Indirect {
+					fmt.Printf("\t%s %s // indirect\n", req.Path, req.Version)
+				} else {
+					fmt.Printf("\t%s %s\n", req.Path, req.Version)
+				}
+			}
+		}
+	}
+	printSectionFooter(opts.quiet)
+}
+
+// 以 module@version 的格式逐行打印第三方模块，可直接交给 xargs go mod download
+// 未在 go.mod 中 require 的模块没有版本，提示到标准错误而不混入列表
+func (da *DependencyAnalyzer) printDownloadList() {
+	for _, m := range da.usedModules("name") {
+		if m.Version == "" {
+			fmt.Fprintf(os.Stderr, "警告: %s 未在 go.mod 中 require，无法确定版本\n", m.Path)
+			continue
+		}
+		fmt.Printf("%s@%s\n", m.Path, m.Version)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// -format ndjson 的一行记录，Type 为 package (新发现的包)、import (导入关系) 或 summary (分析结束后的统计)
+type ndjsonRecord struct {
+	Type     string       `json:"type"`
+	Path     string       `json:"path,omitempty"`
+	Category string       `json:"category,omitempty"`
+	From     string       `json:"from,omitempty"`
+	To       string       `json:"to,omitempty"`
+	File     string       `json:"file,omitempty"`
+	Line     int          `json:"line,omitempty"`
+	Files    int          `json:"files,omitempty"`
+	Stats    *ReportStats `json:"stats,omitempty"`
+}
+
+// 编码为单行 JSON (末尾带换行)
+func marshalNDJSON(rec ndjsonRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(rec); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 分析过程中立即输出一条记录，下游可以边读边处理而不必等待分析结束
+func (da *DependencyAnalyzer) emitNDJSON(rec ndjsonRecord) {
+	data, err := marshalNDJSON(rec)
+	if err != nil {
+		return
+	}
+	da.streamMu.Lock()
+	fmt.Print(string(data))
+	da.streamMu.Unlock()
+}
+
+// 分析结束后输出最后一条 summary 记录: 解析的文件数和各分类的统计
+// (package 和 import 记录已在分析过程中输出)
+func (da *DependencyAnalyzer) writeNDJSON(w io.Writer, entries []string, opts printOptions) error {
+	stats := da.buildReport(opts.sortBy).Stats
+	data, err := marshalNDJSON(ndjsonRecord{Type: "summary", Files: len(da.parsedFiles), Stats: &stats})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// 网络请求的默认超时时间、重试次数和首次重试的等待时间
+const (
+	defaultNetTimeout = 10 * time.Second
+	defaultNetRetries = 2
+	retryBackoff      = 500 * time.Millisecond
+)
+
+// -offline 时所有网络请求返回的错误
+var errOffline = errors.New("离线模式，未访问网络")
+
+// 所有网络请求共用的客户端，统一超时、重试和离线设置
+type netClient struct {
+	client  *http.Client
+	retries int
+	offline bool
+}
+
+// 创建网络客户端，timeout 为单次请求的超时时间
+func newNetClient(timeout time.Duration, retries int, offline bool) *netClient {
+	return &netClient{client: &http.Client{Timeout: timeout}, retries: retries, offline: offline}
+}
+
+// 发送 GET 请求，网络错误、429 和 5xx 响应按指数退避 (0.5s、1s、2s ...) 重试
+func (c *netClient) get(url string) (*http.Response, error) {
+	if c.offline {
+		return nil, errOffline
+	}
+	var lastErr error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryBackoff << (attempt - 1))
+		}
+		resp, err := c.client.Get(url)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("GET %s: %s", url, resp.Status)
+			continue
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
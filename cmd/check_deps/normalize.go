@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// 规范化导入路径: 托管域名 (第一段含点号) 转为小写，去掉多余的 /、./ 和结尾的 /
+// 域名之后的路径区分大小写，保持不变
+func normalizeImportPath(p string) string {
+	if p == "" {
+		return p
+	}
+	p = path.Clean(strings.TrimPrefix(p, "./"))
+	host, rest, found := strings.Cut(p, "/")
+	if !strings.Contains(host, ".") {
+		return p
+	}
+	host = strings.ToLower(host)
+	if !found {
+		return host
+	}
+	return host + "/" + rest
+}
+
+// 启用导入路径规范化，同时规范化模块路径、内部包前缀和 require 路径，保证分类和模块匹配一致
+func (da *DependencyAnalyzer) enableNormalize() {
+	da.normalize = true
+	da.goModPath = normalizeImportPath(da.goModPath)
+	da.internalPrefix = normalizeImportPath(da.internalPrefix)
+	for i, prefix := range da.alsoInternal {
+		da.alsoInternal[i] = normalizeImportPath(prefix)
+	}
+	for i := range da.localModules {
+		da.localModules[i].Path = normalizeImportPath(da.localModules[i].Path)
+	}
+	if da.goMod != nil {
+		for i := range da.goMod.Requires {
+			da.goMod.Requires[i].Path = normalizeImportPath(da.goMod.Requires[i].Path)
+		}
+	}
+}
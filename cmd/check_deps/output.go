@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// 原始标准输出，-o 重定向后错误信息仍然输出到终端
+	console = os.Stdout
+	// -o 指定的输出文件，未指定时为 nil
+	outputFile *os.File
+	// 退出前需要执行的清理函数，如删除临时目录
+	cleanups []func()
+)
+
+// 注册退出前执行的清理函数
+func atExit(fn func()) {
+	cleanups = append(cleanups, fn)
+}
+
+// 执行并清空已注册的清理函数，可重复调用
+func runCleanups() {
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	cleanups = nil
+}
+
+// 将标准输出重定向到文件，自动创建父目录
+func redirectOutput(file string) error {
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	outputFile = f
+	os.Stdout = f
+	return nil
+}
+
+// 向终端打印错误并退出
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(console, "错误: "+format+"\n", args...)
+	runCleanups()
+	os.Exit(1)
+}
+
+// 关闭输出文件后以指定状态码退出，写入失败时退出码为 1
+func finish(code int) {
+	if outputFile != nil {
+		if err := outputFile.Sync(); err != nil {
+			fatalf("写入输出文件失败: %v", err)
+		}
+		if err := outputFile.Close(); err != nil {
+			fatalf("写入输出文件失败: %v", err)
+		}
+	}
+	runCleanups()
+	if code != 0 {
+		os.Exit(code)
+	}
+}
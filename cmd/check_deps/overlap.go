@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 用途相同的一组模块，模式按 -exclude 的规则匹配模块路径 (支持通配符)
+type overlapGroup struct {
+	Purpose string
+	Modules []string
+}
+
+// 功能重叠的已知模块，新增用途或模块直接加在这里
+var overlapGroups = []overlapGroup{
+	{Purpose: "日志", Modules: []string{"github.com/sirupsen/logrus", "go.uber.org/zap", "github.com/rs/zerolog", "github.com/apex/log", "github.com/go-kit/log", "github.com/golang/glog", "k8s.io/klog", "github.com/inconshreveable/log15"}},
+	{Purpose: "YAML", Modules: []string{"gopkg.in/yaml.*", "github.com/goccy/go-yaml", "sigs.k8s.io/yaml", "github.com/ghodss/yaml"}},
+	{Purpose: "JSON", Modules: []string{"github.com/json-iterator/go", "github.com/goccy/go-json", "github.com/bytedance/sonic", "github.com/mailru/easyjson", "github.com/segmentio/encoding"}},
+	{Purpose: "HTTP 路由/Web 框架", Modules: []string{"github.com/gin-gonic/gin", "github.com/labstack/echo", "github.com/gofiber/fiber", "github.com/go-chi/chi", "github.com/gorilla/mux", "github.com/julienschmidt/httprouter", "github.com/zeromicro/go-zero", "github.com/beego/beego"}},
+	{Purpose: "UUID", Modules: []string{"github.com/google/uuid", "github.com/satori/go.uuid", "github.com/gofrs/uuid", "github.com/pborman/uuid"}},
+	{Purpose: "配置加载", Modules: []string{"github.com/spf13/viper", "github.com/knadh/koanf", "github.com/kelseyhightower/envconfig", "github.com/caarlos0/env", "github.com/BurntSushi/toml", "github.com/pelletier/go-toml"}},
+	{Purpose: "命令行", Modules: []string{"github.com/spf13/cobra", "github.com/urfave/cli", "github.com/alecthomas/kingpin", "github.com/jessevdk/go-flags"}},
+	{Purpose: "ORM/SQL 构建", Modules: []string{"gorm.io/gorm", "github.com/jinzhu/gorm", "entgo.io/ent", "github.com/go-xorm/xorm", "xorm.io/xorm", "github.com/jmoiron/sqlx", "github.com/Masterminds/squirrel", "github.com/uptrace/bun"}},
+	{Purpose: "Redis 客户端", Modules: []string{"github.com/go-redis/redis", "github.com/redis/go-redis", "github.com/gomodule/redigo", "github.com/redis/rueidis"}},
+	{Purpose: "错误包装", Modules: []string{"github.com/pkg/errors", "github.com/cockroachdb/errors", "github.com/go-errors/errors", "emperror.dev/errors"}},
+	{Purpose: "测试断言", Modules: []string{"github.com/stretchr/testify", "github.com/smartystreets/goconvey", "github.com/onsi/gomega", "gotest.tools", "github.com/matryer/is"}},
+	{Purpose: "HTTP 客户端", Modules: []string{"github.com/go-resty/resty", "github.com/parnurzeal/gorequest", "github.com/valyala/fasthttp", "github.com/imroc/req"}},
+}
+
+// 同时使用的功能重叠的模块
+type overlap struct {
+	Purpose string
+	Modules []string
+}
+
+// 查找同一用途下同时被导入的多个模块，同一模块的不同主版本视为同一个
+func (da *DependencyAnalyzer) findOverlaps() []overlap {
+	used := da.usedModules("name")
+	var found []overlap
+	for _, group := range overlapGroups {
+		var modules []string
+		seen := make(map[string]bool)
+		for _, m := range used {
+			for _, pattern := range group.Modules {
+				if !matchPathPattern(pattern, m.Path) {
+					continue
+				}
+				seen[pattern] = true
+				modules = append(modules, m.Path)
+				break
+			}
+		}
+		if len(seen) > 1 {
+			found = append(found, overlap{Purpose: group.Purpose, Modules: modules})
+		}
+	}
+	return found
+}
+
+// 打印功能重叠的依赖
+func (da *DependencyAnalyzer) printOverlaps(opts printOptions) {
+	found := da.findOverlaps()
+	printSectionHeader("功能重叠的依赖", opts.quiet)
+	if len(found) == 0 {
+		fmt.Println("✅ 没有发现用途相同的多个第三方模块")
+	} else {
+		fmt.Printf("⚠️  以下 %d 组模块用途相同，可考虑统一:\n", len(found))
+		for _, o := range found {
+			fmt.Printf("  %s: %s\n", o.Purpose, strings.Join(o.Modules, ", "))
+		}
+	}
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 按文件打印各自导入的包
+func (da *DependencyAnalyzer) printPerFile(opts printOptions) {
+	files := make([]string, 0, len(da.fileImports))
+	for file := range da.fileImports {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	printSectionHeader("按文件列出依赖", opts.quiet)
+	for _, file := range files {
+		var pkgs []string
+		seen := make(map[string]bool)
+		for _, imp := range da.fileImports[file] {
+			if seen[imp.Path] {
+				continue
+			}
+			seen[imp.Path] = true
+			if opts.filterType == "all" || opts.filterType == da.category(imp.Path) {
+				pkgs = append(pkgs, imp.Path)
+			}
+		}
+		da.sortPackages(pkgs, opts.sortBy)
+
+		fmt.Printf("%s (%d):\n", da.relPath(file), len(pkgs))
+		for _, pkg := range pkgs {
+			cat := da.categoryOf(pkg)
+			fmt.Printf("  %s %s\n", cat.Icon, colorize(pkg, cat.Color, opts.color))
+		}
+	}
+	printSectionFooter(opts.quiet)
+}
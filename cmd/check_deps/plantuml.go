@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 内部包所在的顶层目录 (相对所属模块)，模块根包返回模块路径
+func (da *DependencyAnalyzer) topLevelDir(pkg string) string {
+	short := da.shortPkg(pkg)
+	if short == pkg {
+		return pkg
+	}
+	dir, _, _ := strings.Cut(short, "/")
+	return dir
+}
+
+// 按顶层目录汇总内部包及目录之间的导入关系 (边的值为包之间的导入数)
+func (da *DependencyAnalyzer) dirGraph(entries []string) (dirs []string, members map[string][]string, graph map[string]map[string]int) {
+	nodes, pkgGraph := da.internalGraph(entries)
+	members = make(map[string][]string)
+	graph = make(map[string]map[string]int)
+	for _, pkg := range nodes {
+		dir := da.topLevelDir(pkg)
+		if members[dir] == nil {
+			dirs = append(dirs, dir)
+		}
+		members[dir] = append(members[dir], pkg)
+		for to := range pkgGraph[pkg] {
+			if toDir := da.topLevelDir(to); toDir != dir {
+				if graph[dir] == nil {
+					graph[dir] = make(map[string]int)
+				}
+				graph[dir][toDir]++
+			}
+		}
+	}
+	return sortedKeys(toSet(dirs)), members, graph
+}
+
+// 以 PlantUML 组件图输出内部包: 顶层目录为 package，其中的内部包为组件，箭头为目录之间的依赖 (标注包之间的导入数)
+func (da *DependencyAnalyzer) writePlantUML(w io.Writer, entries []string, opts printOptions) error {
+	dirs, members, graph := da.dirGraph(entries)
+	ids := make(map[string]string, len(dirs))
+	fmt.Fprintln(w, "@startuml")
+	fmt.Fprintln(w, "skinparam componentStyle rectangle")
+	for i, dir := range dirs {
+		ids[dir] = fmt.Sprintf("d%d", i)
+		fmt.Fprintf(w, "package \"%s\" as %s {\n", dir, ids[dir])
+		for _, pkg := range members[dir] {
+			fmt.Fprintf(w, "  [%s]\n", da.shortPkg(pkg))
+		}
+		fmt.Fprintln(w, "}")
+	}
+	for _, from := range dirs {
+		for _, to := range dirs {
+			if n := graph[from][to]; n > 0 {
+				fmt.Fprintf(w, "%s --> %s : %d\n", ids[from], ids[to], n)
+			}
+		}
+	}
+	_, err := fmt.Fprintln(w, "@enduml")
+	return err
+}
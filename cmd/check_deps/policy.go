@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 依赖数量阈值，0 表示不限制
+type thresholds struct {
+	maxThirdParty    int
+	maxTotal         int
+	maxThirdPartyPct float64 // 第三方库占依赖总数的百分比上限
+}
+
+// 检查依赖数量是否超出阈值，返回超限的描述
+func (da *DependencyAnalyzer) checkThresholds(t thresholds) []string {
+	var breaches []string
+	// 只通过 // depcheck:ignore 导入引入的包不计入阈值
+	thirdParty := da.checkedCount(da.thirdParty)
+	if t.maxThirdParty > 0 && thirdParty > t.maxThirdParty {
+		breaches = append(breaches, fmt.Sprintf("第三方库数量 %d 超过上限 %d (-max-third-party)", thirdParty, t.maxThirdParty))
+	}
+	total := 0
+	for _, cat := range da.categories() {
+		total += da.checkedCount(da.categorySet(cat.Key))
+	}
+	if t.maxTotal > 0 && total > t.maxTotal {
+		breaches = append(breaches, fmt.Sprintf("依赖总数 %d 超过上限 %d (-max-total)", total, t.maxTotal))
+	}
+	// 与统计信息中的占比使用相同的计算方式
+	if t.maxThirdPartyPct > 0 && total > 0 {
+		if pct := float64(thirdParty) / float64(total) * 100; pct > t.maxThirdPartyPct {
+			breaches = append(breaches, fmt.Sprintf("第三方库占比 %.1f%% 超过上限 %g%% (-max-third-party-pct，%d / %d)", pct, t.maxThirdPartyPct, thirdParty, total))
+		}
+	}
+	return breaches
+}
+
+// 打印阈值检查结果
+func printThresholdBreaches(breaches []string) {
+	fmt.Println()
+	if len(breaches) == 0 {
+		fmt.Println("✅ 依赖数量未超出阈值")
+		return
+	}
+	fmt.Printf("❌ 依赖数量超出阈值 (%d):\n", len(breaches))
+	for _, b := range breaches {
+		fmt.Printf("  %s\n", b)
+	}
+}
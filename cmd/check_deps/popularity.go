@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 包的文件级流行度: 导入该包的不同文件数
+type pkgPopularity struct {
+	Pkg   string
+	Files int
+}
+
+// 统计每个已发现的包被多少个不同的文件导入，按文件数从多到少排序，相同时按包名
+func (da *DependencyAnalyzer) popularity(filterType string) []pkgPopularity {
+	var result []pkgPopularity
+	for _, cat := range append(da.categories(), catCgo) {
+		if filterType != "all" && filterType != cat.Key {
+			continue
+		}
+		for pkg := range da.categorySet(cat.Key) {
+			files := make(map[string]bool)
+			for _, site := range da.sites[pkg] {
+				files[site.File] = true
+			}
+			result = append(result, pkgPopularity{Pkg: pkg, Files: len(files)})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Files != result[j].Files {
+			return result[i].Files > result[j].Files
+		}
+		return result[i].Pkg < result[j].Pkg
+	})
+	return result
+}
+
+// 打印包的文件级流行度，-top-n 限制列出的数量
+func (da *DependencyAnalyzer) printPopularity(opts printOptions) {
+	pops := da.popularity(opts.filterType)
+
+	printSectionHeader("按导入文件数排列的包", opts.quiet)
+	fmt.Printf("已分析文件: %d 个\n", len(da.fileImports))
+	shown := pops
+	if opts.topN > 0 && len(pops) > opts.topN {
+		shown = pops[:opts.topN]
+	}
+	for _, p := range shown {
+		cat := da.categoryOf(p.Pkg)
+		fmt.Printf("  %5d  %s %s\n", p.Files, cat.Icon, colorize(p.Pkg, cat.Color, opts.color))
+	}
+	if len(shown) < len(pops) {
+		fmt.Printf(msg.More, len(pops)-len(shown))
+	}
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// 进度刷新的最小间隔，避免频繁写终端拖慢分析
+const progressInterval = 100 * time.Millisecond
+
+// 在标准错误中原地显示的分析进度
+type progress struct {
+	enabled bool
+	last    time.Time
+	shown   bool
+}
+
+// 标准错误是终端时才显示进度
+func newProgress(enabled bool) *progress {
+	return &progress{enabled: enabled && isTerminal(os.Stderr)}
+}
+
+// 刷新进度，距上次刷新不足 progressInterval 时跳过
+func (p *progress) update(files, pkgs int) {
+	if !p.enabled || time.Since(p.last) < progressInterval {
+		return
+	}
+	p.last = time.Now()
+	p.shown = true
+	fmt.Fprintf(os.Stderr, "\r\033[K已解析 %d 个文件，发现 %d 个包", files, pkgs)
+}
+
+// 清除进度行
+func (p *progress) done() {
+	if p.shown {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		p.shown = false
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 结构化的分析结果，用于 JSON 输出和基线文件
+type Report struct {
+	SchemaVersion string              `json:"schemaVersion"` // 报告结构的版本，见 reportSchemaVersion
+	Stdlib        []string            `json:"stdlib"`
+	Extended      []string            `json:"extended,omitempty"`
+	ThirdParty    []string            `json:"thirdParty"`
+	Internal      []string            `json:"internal"`
+	Custom        map[string][]string `json:"custom,omitempty"` // -classify-rules 定义的分类
+	Cgo           bool                `json:"cgo,omitempty"`
+	Stats         ReportStats         `json:"stats"`
+}
+
+// 各分类的包数量
+type ReportStats struct {
+	Total      int            `json:"total"`
+	Stdlib     int            `json:"stdlib"`
+	Extended   int            `json:"extended,omitempty"`
+	ThirdParty int            `json:"thirdParty"`
+	Internal   int            `json:"internal"`
+	Custom     map[string]int `json:"custom,omitempty"`
+}
+
+// 生成结构化的分析结果
+func (da *DependencyAnalyzer) buildReport(sortBy string) *Report {
+	r := &Report{
+		SchemaVersion: reportSchemaVersion,
+		Stdlib:        da.sortedSet(da.stdlib, sortBy),
+		Extended:      da.sortedSet(da.extended, sortBy),
+		ThirdParty:    da.sortedSet(da.thirdParty, sortBy),
+		Internal:      da.sortedSet(da.internal, sortBy),
+		Cgo:           len(da.cgo) > 0,
+	}
+	for _, cat := range da.customCats {
+		if r.Custom == nil {
+			r.Custom = make(map[string][]string)
+		}
+		r.Custom[cat.Key] = da.sortedSet(da.custom[cat.Key], sortBy)
+	}
+	r.computeStats()
+	return r
+}
+
+// 根据包列表重新计算统计信息
+func (r *Report) computeStats() {
+	r.Stats = ReportStats{
+		Total:      len(r.Stdlib) + len(r.Extended) + len(r.ThirdParty) + len(r.Internal),
+		Stdlib:     len(r.Stdlib),
+		Extended:   len(r.Extended),
+		ThirdParty: len(r.ThirdParty),
+		Internal:   len(r.Internal),
+	}
+	for name, pkgs := range r.Custom {
+		if r.Stats.Custom == nil {
+			r.Stats.Custom = make(map[string]int)
+		}
+		r.Stats.Custom[name] = len(pkgs)
+		r.Stats.Total += len(pkgs)
+	}
+}
+
+// 将集合转换为排序后的列表
+func (da *DependencyAnalyzer) sortedSet(set map[string]bool, sortBy string) []string {
+	pkgs := make([]string, 0, len(set))
+	for pkg := range set {
+		pkgs = append(pkgs, pkg)
+	}
+	da.sortPackages(pkgs, sortBy)
+	return pkgs
+}
+
+// 序列化为缩进的 JSON，不转义 HTML 字符
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 读取之前生成的 JSON 报告
+func loadReport(file string) (*Report, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %v", file, err)
+	}
+	// 没有 schemaVersion 的是加入版本之前生成的报告，结构与 1.0 相同
+	if major, _, _ := strings.Cut(r.SchemaVersion, "."); r.SchemaVersion != "" && major != strings.Split(reportSchemaVersion, ".")[0] {
+		return nil, fmt.Errorf("%s 的 schemaVersion %s 与当前版本 %s 不兼容，请重新生成", file, r.SchemaVersion, reportSchemaVersion)
+	}
+	return &r, nil
+}
+
+// 写入 JSON 报告
+func writeReport(file string, r *Report) error {
+	data, err := marshalJSON(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SARIF 2.1.0 报告结构，只包含用到的字段
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// 将检查结果转换为 SARIF 报告
+func (da *DependencyAnalyzer) buildSarif(findings []finding) sarifReport {
+	rules := make([]sarifRule, 0, len(allRules))
+	ruleIndex := make(map[string]int, len(allRules))
+	for i, r := range allRules {
+		rules = append(rules, sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}})
+		ruleIndex[r.ID] = i
+	}
+	root := da.sarifRoot()
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		level := f.Rule.Level
+		if level == "" {
+			level = "error"
+		}
+		result := sarifResult{
+			RuleID:    f.Rule.ID,
+			RuleIndex: ruleIndex[f.Rule.ID],
+			Level:     level,
+			Message:   sarifMessage{Text: f.Message},
+		}
+		if f.File != "" {
+			loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(root, f.File), URIBaseID: "%SRCROOT%"},
+			}}
+			if f.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+			}
+			result.Locations = []sarifLocation{loc}
+		}
+		results = append(results, result)
+	}
+
+	return sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "check_deps", Version: readBuildInfo().Version, Rules: rules}},
+			Results: results,
+		}},
+	}
+}
+
+// 获取相对项目根目录的路径 (使用 / 分隔)，不在项目内时返回原路径
+func (da *DependencyAnalyzer) relPath(file string) string {
+	rel, err := filepath.Rel(da.projectPath, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// SARIF 中文件路径的根目录: 项目所在 git 仓库的根目录，使模块位于仓库子目录时代码扫描仍能定位到导入行；
+// 不在 git 仓库中时使用项目根目录
+func (da *DependencyAnalyzer) sarifRoot() string {
+	if out, err := da.git("rev-parse", "--show-toplevel"); err == nil {
+		if root := strings.TrimSpace(string(out)); root != "" {
+			return root
+		}
+	}
+	return da.projectPath
+}
+
+// 相对根目录的文件路径 (使用 / 分隔)，不在根目录内时返回原路径
+func sarifURI(root, file string) string {
+	rel, err := filepath.Rel(realPath(root), realPath(file))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// 写入 SARIF 报告文件
+func (da *DependencyAnalyzer) writeSarif(outFile string) error {
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+	if err := da.writeSarifTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// 以 SARIF 2.1.0 输出检查结果 (-format sarif)
+func (da *DependencyAnalyzer) writeSarifTo(w io.Writer) error {
+	data, err := marshalJSON(da.buildSarif(da.collectFindings()))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSON Schema 的版本
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON 报告结构的版本，写入报告的 schemaVersion 字段。
+// 新增可选字段时增加次版本号；删除、重命名字段或改变字段类型时增加主版本号，并同时修改 $id
+const reportSchemaVersion = "1.0"
+
+// 按 JSON 报告 (Report) 的结构通过反射生成 JSON Schema，字段和 omitempty 与 json 标签保持一致
+func reportSchema() map[string]any {
+	defs := make(map[string]any)
+	schema := structSchema(reflect.TypeOf(Report{}), defs)
+	schema["$schema"] = schemaDialect
+	schema["$id"] = "https://github.com/geekeryy/scripts/cmd/check_deps/report.v" + strings.Split(reportSchemaVersion, ".")[0] + ".schema.json"
+	schema["title"] = "check_deps report " + reportSchemaVersion
+	// 固定 schemaVersion 的取值，下游可据此判断报告是否与 schema 匹配
+	schema["properties"].(map[string]any)["schemaVersion"] = map[string]any{"type": "string", "const": reportSchemaVersion}
+	schema["description"] = "check_deps 的 JSON 报告 (-baseline 基线文件、-merge 的输入)"
+	if len(defs) > 0 {
+		schema["$defs"] = defs
+	}
+	return schema
+}
+
+// 结构体的 schema: 导出字段按 json 标签命名，没有 omitempty 的字段为必填
+func structSchema(t reflect.Type, defs map[string]any) map[string]any {
+	props := make(map[string]any)
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = typeSchema(f.Type, defs)
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// 字段类型的 schema，具名结构体放入 $defs 并引用
+func typeSchema(t reflect.Type, defs map[string]any) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), defs)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), defs)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, defs)
+		}
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil // 先占位，避免递归类型死循环
+			defs[t.Name()] = structSchema(t, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	}
+	return map[string]any{}
+}
+
+// 打印 JSON 报告的 JSON Schema
+func printSchema() error {
+	data, err := marshalJSON(reportSchema())
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(data))
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 健康度评分的默认权重
+//
+//	third-party  第三方库占比，占比越低得分越高
+//	cycles       内部包导入循环，每个循环扣 25%
+//	domains      第三方库的托管域名数量，超过 3 个后每多一个扣 10%
+//	versions     同一模块同时使用多个主版本，每处冲突扣 25%
+//
+// 可通过 -score-weights (或配置文件的 score-weights) 覆盖，如 "third-party=50,cycles=50"
+var defaultScoreWeights = map[string]float64{
+	"third-party": 40,
+	"cycles":      30,
+	"domains":     15,
+	"versions":    15,
+}
+
+// 评分项的展示顺序
+var scoreFactorOrder = []string{"third-party", "cycles", "domains", "versions"}
+
+// 单个评分项
+type scoreFactor struct {
+	Name   string
+	Detail string
+	Score  float64 // 0~1，1 表示健康
+	Weight float64
+}
+
+// 依赖健康度评分
+type healthScore struct {
+	Total   float64 // 0~100
+	Factors []scoreFactor
+}
+
+// 解析权重覆盖，未指定的评分项使用默认权重
+func parseScoreWeights(spec string) (map[string]float64, error) {
+	weights := make(map[string]float64, len(defaultScoreWeights))
+	for name, w := range defaultScoreWeights {
+		weights[name] = w
+	}
+	for _, item := range strings.Split(spec, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if _, known := defaultScoreWeights[name]; !ok || !known {
+			return nil, fmt.Errorf("无效的权重 %q，格式为 <评分项>=<权重>，评分项: %s", item, strings.Join(scoreFactorOrder, ", "))
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("无效的权重 %q", item)
+		}
+		weights[name] = w
+	}
+	return weights, nil
+}
+
+// 第三方库 (含扩展库) 的托管域名
+func (da *DependencyAnalyzer) hostingDomains() []string {
+	seen := make(map[string]bool)
+	for _, pkg := range da.externalPackages() {
+		seen[strings.Split(pkg, "/")[0]] = true
+	}
+	return sortedKeys(seen)
+}
+
+// 同时使用了多个主版本的模块，返回去掉版本后缀的模块路径
+func (da *DependencyAnalyzer) majorVersionConflicts() []string {
+	versions := make(map[string]map[string]bool)
+	for _, m := range da.usedModules("name") {
+		base := m.Path
+		if idx := strings.LastIndex(base, "/"); idx >= 0 && majorVersionRe.MatchString(base[idx+1:]) {
+			base = base[:idx]
+		}
+		if versions[base] == nil {
+			versions[base] = make(map[string]bool)
+		}
+		versions[base][m.Path] = true
+	}
+	var conflicts []string
+	for base, paths := range versions {
+		if len(paths) > 1 {
+			conflicts = append(conflicts, base)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// 计算依赖健康度评分
+func (da *DependencyAnalyzer) computeScore(weights map[string]float64) healthScore {
+	total := da.total()
+	external := len(da.externalPackages())
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(external) / float64(total)
+	}
+	cycles := len(da.findCycles())
+	domains := len(da.hostingDomains())
+	conflicts := da.majorVersionConflicts()
+
+	factors := map[string]scoreFactor{
+		"third-party": {Detail: fmt.Sprintf("第三方库占比 %.1f%%", ratio*100), Score: 1 - ratio},
+		"cycles":      {Detail: fmt.Sprintf("%d 个导入循环", cycles), Score: max(0, 1-0.25*float64(cycles))},
+		"domains":     {Detail: fmt.Sprintf("%d 个托管域名", domains), Score: max(0, 1-0.1*float64(max(0, domains-3)))},
+		"versions":    {Detail: fmt.Sprintf("%d 个多主版本冲突", len(conflicts)), Score: max(0, 1-0.25*float64(len(conflicts)))},
+	}
+
+	var hs healthScore
+	weightSum := 0.0
+	for _, name := range scoreFactorOrder {
+		f := factors[name]
+		f.Name = name
+		f.Weight = weights[name]
+		weightSum += f.Weight
+		hs.Total += f.Score * f.Weight
+		hs.Factors = append(hs.Factors, f)
+	}
+	if weightSum > 0 {
+		hs.Total = hs.Total / weightSum * 100
+	}
+	return hs
+}
+
+// 打印健康度评分及各项明细
+func (da *DependencyAnalyzer) printScore(hs healthScore, opts printOptions) {
+	printSectionHeader("依赖健康度", opts.quiet)
+	fmt.Printf("评分: %.0f / 100\n", hs.Total)
+	for _, f := range hs.Factors {
+		fmt.Printf("  - %-12s %5.1f%% (权重 %g)  %s\n", f.Name, f.Score*100, f.Weight, f.Detail)
+	}
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 相对 git 引用新引入的第三方库及引入它的文件
+type introducedDep struct {
+	Pkg   string
+	Files []string
+}
+
+// 在项目目录下执行 git 命令
+func (da *DependencyAnalyzer) git(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = da.projectPath
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git %s: %v", strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+// 找出自 ref 以来改动的 .go 文件中新引入的第三方库 (含扩展库)
+func (da *DependencyAnalyzer) analyzeSince(ref string) ([]introducedDep, []string, error) {
+	// 检测重命名，重命名的文件按原路径读取改动前的版本
+	out, err := da.git("diff", "--name-status", "-M", "--relative", ref, "--", "*.go")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changed []string
+	oldPkgs := make(map[string]bool)
+	newPkgs := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// 每行为 "<状态>\t<路径>"，重命名为 "R<相似度>\t<原路径>\t<新路径>"
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		name, oldName := fields[len(fields)-1], fields[1]
+		if !da.allFiles && strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		changed = append(changed, name)
+
+		// 改动前的版本，文件在 ref 中不存在时视为没有导入
+		if src, err := da.git("show", ref+":./"+oldName); err == nil {
+			if imports, err := parseImports(oldName, bytes.NewReader(src)); err == nil {
+				for _, imp := range imports {
+					if imp.Ignored {
+						continue
+					}
+					oldPkgs[imp.Path] = true
+				}
+			}
+		}
+
+		// 当前版本，文件已删除时跳过
+		file := filepath.Join(da.projectPath, filepath.FromSlash(name))
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		imports, err := da.parseFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("解析文件 %s 失败: %v", name, err)
+		}
+		for _, imp := range imports {
+			if imp.Ignored {
+				continue
+			}
+			newPkgs[imp.Path] = append(newPkgs[imp.Path], name)
+		}
+	}
+
+	var introduced []introducedDep
+	for pkg, files := range newPkgs {
+		if oldPkgs[pkg] || da.isExcluded(pkg) {
+			continue
+		}
+		if category := da.classify(pkg); category != "third-party" && category != "extended" {
+			continue
+		}
+		introduced = append(introduced, introducedDep{Pkg: pkg, Files: files})
+	}
+	sort.Slice(introduced, func(i, j int) bool { return introduced[i].Pkg < introduced[j].Pkg })
+	return introduced, changed, nil
+}
+
+// 打印新引入的第三方库
+func printIntroduced(ref string, changed []string, introduced []introducedDep) {
+	fmt.Printf("自 %s 以来改动的 Go 文件: %d 个\n", ref, len(changed))
+	if len(introduced) == 0 {
+		fmt.Println("✅ 没有新引入的第三方库")
+		return
+	}
+	fmt.Printf("❌ 新引入的第三方库 (%d):\n", len(introduced))
+	for _, dep := range introduced {
+		fmt.Printf("  %s (%s)\n", dep.Pkg, strings.Join(dep.Files, ", "))
+	}
+}
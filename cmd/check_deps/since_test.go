@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// 在目录中执行 git 命令，不依赖全局的用户配置
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// 只重命名文件时，其中的第三方库不算新引入；重命名后新增的导入照常报告
+func TestAnalyzeSinceRename(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("未找到 git")
+	}
+	// 函数体让改动后的 b 仍与原文件足够相似，被识别为重命名
+	body := "\nfunc Add(a, b int) int {\n\treturn a + b\n}\n\nfunc Sub(a, b int) int {\n\treturn a - b\n}\n\nfunc Mul(a, b int) int {\n\treturn a * b\n}\n"
+	root := writeModule(t, map[string]string{
+		"a/old.go": "package a\n\nimport _ \"github.com/pkg/errors\"\n",
+		"b/b.go":   "package b\n\nimport (\n\t_ \"github.com/foo/bar\"\n)\n" + body,
+	})
+	runGit(t, root, "init", "-q")
+	runGit(t, root, "add", "-A")
+	runGit(t, root, "commit", "-q", "-m", "init")
+
+	runGit(t, root, "mv", "a/old.go", "a/new.go")
+	runGit(t, root, "mv", "b/b.go", "b/moved.go")
+	if err := os.WriteFile(filepath.Join(root, "b", "moved.go"), []byte("package b\n\nimport (\n\t_ \"github.com/foo/bar\"\n\t_ \"github.com/foo/baz\"\n)\n"+body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, root, "commit", "-q", "-am", "rename")
+
+	da := NewDependencyAnalyzer(root)
+	introduced, changed, err := da.analyzeSince("HEAD~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 {
+		t.Errorf("改动的文件应为 a/new.go 和 b/moved.go，实际为 %v", changed)
+	}
+	if len(introduced) != 1 || introduced[0].Pkg != "github.com/foo/baz" {
+		t.Errorf("新引入的第三方库应只有 github.com/foo/baz，实际为 %+v", introduced)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 模块在模块缓存中的代码量
+type moduleSize struct {
+	Path    string
+	Version string
+	Lines   int
+	Bytes   int64
+	Found   bool
+}
+
+// 模块缓存目录，优先使用 GOMODCACHE
+func (da *DependencyAnalyzer) modCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	goPath := da.goPath
+	if list := filepath.SplitList(goPath); len(list) > 0 {
+		goPath = list[0]
+	}
+	return filepath.Join(goPath, "pkg", "mod")
+}
+
+// 按模块缓存的规则转义模块路径: 大写字母转为 "!" 加小写字母
+func escapeModulePath(path string) string {
+	var sb strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			sb.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// 模块在模块缓存中的目录
+func (da *DependencyAnalyzer) moduleCachePath(path, version string) string {
+	return filepath.Join(da.modCacheDir(), filepath.FromSlash(escapeModulePath(path))+"@"+escapeModulePath(version))
+}
+
+// 统计目录下非测试 .go 文件的行数和字节数，跳过 testdata 和 vendor
+func countGoCode(root string) (int, int64, error) {
+	lines := 0
+	var size int64
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && (d.Name() == "testdata" || d.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		lines += bytes.Count(data, []byte("\n"))
+		size += int64(len(data))
+		return nil
+	})
+	return lines, size, err
+}
+
+// 估算每个实际使用的第三方模块的代码量，按行数从大到小排序
+func (da *DependencyAnalyzer) estimateModuleSizes() []moduleSize {
+	var sizes []moduleSize
+	for _, m := range da.usedModules("name") {
+		ms := moduleSize{Path: m.Path, Version: m.Version}
+		if m.Version != "" {
+			if lines, size, err := countGoCode(da.moduleCachePath(m.Path, m.Version)); err == nil {
+				ms.Lines, ms.Bytes, ms.Found = lines, size, true
+			}
+		}
+		sizes = append(sizes, ms)
+	}
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Lines > sizes[j].Lines })
+	return sizes
+}
+
+// 打印模块代码量估算
+func (da *DependencyAnalyzer) printSizeEstimate(opts printOptions) {
+	sizes := da.estimateModuleSizes()
+	totalLines := 0
+	for _, ms := range sizes {
+		totalLines += ms.Lines
+	}
+
+	printSectionHeader("第三方模块代码量估算", opts.quiet)
+	for _, ms := range sizes {
+		if !ms.Found {
+			fmt.Printf("  %-50s 未在模块缓存中找到\n", ms.Path)
+			continue
+		}
+		pct := 0.0
+		if totalLines > 0 {
+			pct = float64(ms.Lines) / float64(totalLines) * 100
+		}
+		fmt.Printf("  %-50s %8d 行 %9.1f KB (%.1f%%)\n", ms.Path+"@"+ms.Version, ms.Lines, float64(ms.Bytes)/1024, pct)
+	}
+	fmt.Printf("合计: %d 行 (按模块缓存中的非测试 Go 代码估算，仅供参考)\n", totalLines)
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 分类对应的输出文件名，自定义分类名中的路径分隔符替换为 _
+func splitFileName(key, ext string) string {
+	return strings.NewReplacer("/", "_", `\`, "_").Replace(key) + ext
+}
+
+// 将每个分类的包 (按包名排序) 写入目录下的 <分类>.txt (每行一个)，-format json 时写入
+// <分类>.json (字符串数组)，目录不存在时自动创建。
+// 没有包的分类也会写入空文件，便于流水线按固定的文件名读取
+func (da *DependencyAnalyzer) writeSplitOutput(dir string, format outputFormat) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, cat := range da.categories() {
+		pkgs := da.sortedSet(da.categorySet(cat.Key), "name")
+		var data []byte
+		ext := ".txt"
+		if format.Name == "json" {
+			var err error
+			if data, err = marshalJSON(pkgs); err != nil {
+				return nil, err
+			}
+			ext = format.Ext
+		} else {
+			for _, pkg := range pkgs {
+				data = append(data, pkg+"\n"...)
+			}
+		}
+		file := filepath.Join(dir, splitFileName(cat.Key, ext))
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 按第一段路径对标准库分组，单段的包 (如 fmt、os) 归入 core
+func groupStdlib(pkgs map[string]bool) map[string][]string {
+	groups := make(map[string][]string)
+	for pkg := range pkgs {
+		group := "core"
+		if idx := strings.Index(pkg, "/"); idx >= 0 {
+			group = pkg[:idx]
+		}
+		groups[group] = append(groups[group], pkg)
+	}
+	for _, list := range groups {
+		sort.Strings(list)
+	}
+	return groups
+}
+
+// 打印标准库分组统计
+func (da *DependencyAnalyzer) printStdlibGroups(opts printOptions) {
+	groups := groupStdlib(da.stdlib)
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if opts.sortBy == "count" && len(groups[names[i]]) != len(groups[names[j]]) {
+			return len(groups[names[i]]) > len(groups[names[j]])
+		}
+		return names[i] < names[j]
+	})
+
+	printSectionHeader("标准库分组", opts.quiet)
+	for _, name := range names {
+		fmt.Printf("%s (%d)\n", colorize(name, colorGreen, opts.color), len(groups[name]))
+		if opts.verbose {
+			for _, pkg := range groups[name] {
+				fmt.Printf("  %s\n", pkg)
+			}
+		}
+	}
+	printSectionFooter(opts.quiet)
+}
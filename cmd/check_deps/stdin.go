@@ -0,0 +1,24 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// -f - 时标准输入的默认虚拟文件名
+const defaultStdinName = "stdin.go"
+
+// 读取标准输入中的 Go 源码，以当前目录下的虚拟文件作为入口文件，返回其路径
+func (da *DependencyAnalyzer) readStdin(name string) (string, error) {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	file, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+	da.stdinFile, da.stdinSrc = file, src
+	return file, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 外部包的源码目录: 本地 replace 的目录或模块缓存中的目录，go.mod 中没有版本时返回 false
+func (da *DependencyAnalyzer) externalPkgDir(pkg string) (string, bool) {
+	mod, version := da.moduleOfPkg(pkg)
+	rel := filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(pkg, mod), "/"))
+	if da.goMod != nil {
+		for _, r := range da.goMod.Replaces {
+			if r.Old != mod || (r.OldVersion != "" && r.OldVersion != version) {
+				continue
+			}
+			if r.isLocal() {
+				dir := filepath.FromSlash(r.New)
+				if !filepath.IsAbs(dir) {
+					dir = filepath.Join(da.projectPath, dir)
+				}
+				return filepath.Join(dir, rel), true
+			}
+			mod, version = r.New, r.NewVersion
+			break
+		}
+	}
+	if version == "" {
+		return "", false
+	}
+	return filepath.Join(da.moduleCachePath(mod, version), rel), true
+}
+
+// 读取目录中包文档的第一句，优先 doc.go，没有文档时返回空
+func packageSynopsis(dir string) string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return ""
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return filepath.Base(files[i]) == "doc.go" && filepath.Base(files[j]) != "doc.go"
+	})
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil || f.Doc == nil {
+			continue
+		}
+		if s := new(doc.Package).Synopsis(f.Doc.Text()); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// 读取外部包 (verbose 时还包括标准库) 的文档摘要，无法读取时记为空
+func (da *DependencyAnalyzer) loadSynopses(verbose bool) {
+	da.synopses = make(map[string]string)
+	for _, pkg := range da.externalPackages() {
+		if dir, ok := da.externalPkgDir(pkg); ok {
+			da.synopses[pkg] = packageSynopsis(dir)
+		} else {
+			da.synopses[pkg] = ""
+		}
+	}
+	if verbose {
+		for pkg := range da.stdlib {
+			da.synopses[pkg] = packageSynopsis(filepath.Join(build.Default.GOROOT, "src", filepath.FromSlash(pkg)))
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// -template 中可用的函数
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// 解析 -template 的值: 以 @ 开头时从文件读取模板，否则值本身就是模板
+func parseOutputTemplate(value string) (*template.Template, error) {
+	text := value
+	if file, ok := strings.CutPrefix(value, "@"); ok {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		text = string(data)
+	}
+	return template.New("output").Funcs(templateFuncs).Parse(text)
+}
+
+// 以 Go text/template 渲染分析结果视图 (reportView) 的输出格式
+func templateFormat(tmpl *template.Template) outputFormat {
+	return outputFormat{
+		Name: "template",
+		Ext:  ".txt",
+		Write: func(da *DependencyAnalyzer, w io.Writer, entries []string, opts printOptions) error {
+			return tmpl.Execute(w, da.buildView(entries, opts))
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// -flag-test-imports 默认的测试辅助包路径特征
+const defaultTestImportPatterns = "testutil,mock,testing"
+
+// 生产代码导入的测试辅助包
+type testImport struct {
+	Pkg     string
+	Pattern string // 匹配的路径特征
+	Site    importSite
+}
+
+// 解析逗号分隔的路径特征
+func parseTestImportPatterns(list string) []string {
+	var patterns []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return patterns
+}
+
+// 包路径中包含的第一个测试辅助包特征
+func (da *DependencyAnalyzer) testHelperPattern(pkg string) (string, bool) {
+	for _, p := range da.testHelpers {
+		if strings.Contains(pkg, p) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// 查找生产代码对测试辅助包的导入: 跳过 _test.go 文件，以及导入方本身也是测试辅助包的情况
+func (da *DependencyAnalyzer) findTestImports() []testImport {
+	var found []testImport
+	for _, cat := range da.categories() {
+		for _, pkg := range sortedKeys(da.categorySet(cat.Key)) {
+			pattern, ok := da.testHelperPattern(pkg)
+			if !ok {
+				continue
+			}
+			for _, site := range checkedSites(da.sites[pkg]) {
+				if strings.HasSuffix(site.File, "_test.go") {
+					continue
+				}
+				if _, helper := da.testHelperPattern(site.From); helper {
+					continue
+				}
+				found = append(found, testImport{Pkg: pkg, Pattern: pattern, Site: site})
+			}
+		}
+	}
+	return found
+}
+
+// 打印生产代码导入的测试辅助包
+func (da *DependencyAnalyzer) printTestImports(found []testImport) {
+	fmt.Println()
+	if len(found) == 0 {
+		fmt.Printf("✅ 生产代码没有导入测试辅助包 (%s)\n", strings.Join(da.testHelpers, ", "))
+		return
+	}
+	fmt.Printf("❌ 生产代码导入了测试辅助包 (%d):\n", len(found))
+	for _, t := range found {
+		fmt.Printf("  %s (匹配 %s)  %s:%d\n", t.Pkg, t.Pattern, da.relPath(t.Site.File), t.Site.Line)
+	}
+}
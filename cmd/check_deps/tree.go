@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 导入树中包的子节点: 按包名排序，-type 只保留该分类的包 (可展开的包始终保留，以便看到传递路径)
+func (da *DependencyAnalyzer) treeChildren(pkg, filterType string) []string {
+	var children []string
+	for to := range da.edges[pkg] {
+		if to == pkg || !da.isClassified(to) {
+			continue
+		}
+		if filterType != "all" && da.category(to) != filterType && len(da.edges[to]) == 0 {
+			continue
+		}
+		children = append(children, to)
+	}
+	sort.Strings(children)
+	return children
+}
+
+// 以缩进的树打印从入口包开始的导入层级: 祖先链中已出现的包标记为 ↻ (导入循环)，
+// 已在其他位置展开过的包标记为 … 且不再展开
+func (da *DependencyAnalyzer) printImportTree(entries []string, opts printOptions) {
+	printSectionHeader("导入树", opts.quiet)
+	expanded := make(map[string]bool)
+	onPath := make(map[string]bool)
+
+	var walk func(pkg, prefix string)
+	walk = func(pkg, prefix string) {
+		children := da.treeChildren(pkg, opts.filterType)
+		for i, child := range children {
+			branch, indent := "├── ", "│   "
+			if i == len(children)-1 {
+				branch, indent = "└── ", "    "
+			}
+			line := prefix + branch + colorize(child, da.categoryOf(child).Color, opts.color)
+			switch {
+			case onPath[child]:
+				fmt.Println(line + " ↻")
+			case expanded[child] && len(da.treeChildren(child, opts.filterType)) > 0:
+				fmt.Println(line + " …")
+			default:
+				fmt.Println(line)
+				expanded[child] = true
+				onPath[child] = true
+				walk(child, prefix+indent)
+				onPath[child] = false
+			}
+		}
+	}
+
+	for _, root := range da.entryPkgs(entries) {
+		fmt.Println(colorize(root, da.categoryOf(root).Color, opts.color))
+		expanded[root] = true
+		onPath[root] = true
+		walk(root, "")
+		onPath[root] = false
+	}
+	fmt.Println("(↻ 导入循环，… 已在上方展开)")
+	printSectionFooter(opts.quiet)
+}
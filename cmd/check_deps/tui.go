@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 交互式浏览中的一行
+type tuiRow struct {
+	pkg   string
+	level int // 展开层级，0 为分类下的包
+}
+
+// 交互式浏览的状态
+type tuiState struct {
+	da       *DependencyAnalyzer
+	cats     []categoryInfo
+	catIdx   int
+	filter   string
+	cursor   int
+	offset   int
+	expanded map[string]bool
+	rows     []tuiRow
+}
+
+// 启动交互式浏览: Tab 切换分类，↑/↓ 移动，Enter 展开/收起内部包的导入，
+// 直接输入文字实时过滤，Backspace 删除，Ctrl+U 清空过滤，Ctrl+C 退出
+func (da *DependencyAnalyzer) runTUI() error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return errors.New("-tui 需要在终端中运行 (stdin 和 stdout 都必须是 TTY)")
+	}
+
+	restore, err := enableRawMode()
+	if err != nil {
+		return fmt.Errorf("无法切换终端模式: %v", err)
+	}
+	defer restore()
+	// 退出时清屏并显示光标
+	defer fmt.Print("\033[2J\033[H\033[?25h")
+	fmt.Print("\033[?25l")
+
+	st := &tuiState{da: da, cats: da.categories(), expanded: make(map[string]bool)}
+	st.rebuild()
+
+	in := bufio.NewReader(os.Stdin)
+	for {
+		st.render(terminalHeight())
+
+		b, err := in.ReadByte()
+		if err != nil {
+			return nil
+		}
+		switch b {
+		case 3, 4: // Ctrl+C, Ctrl+D
+			return nil
+		case '\t':
+			st.catIdx = (st.catIdx + 1) % len(st.cats)
+			st.cursor, st.offset = 0, 0
+		case '\r', '\n':
+			if st.cursor < len(st.rows) {
+				pkg := st.rows[st.cursor].pkg
+				if da.internal[pkg] {
+					st.expanded[pkg] = !st.expanded[pkg]
+				}
+			}
+		case 127, 8: // Backspace
+			if st.filter != "" {
+				st.filter = st.filter[:len(st.filter)-1]
+				st.cursor, st.offset = 0, 0
+			}
+		case 21: // Ctrl+U
+			st.filter = ""
+			st.cursor, st.offset = 0, 0
+		case 27: // 方向键: ESC [ A/B/C/D
+			if next, _ := in.ReadByte(); next != '[' {
+				continue
+			}
+			switch key, _ := in.ReadByte(); key {
+			case 'A':
+				st.cursor--
+			case 'B':
+				st.cursor++
+			case 'C':
+				st.catIdx = (st.catIdx + 1) % len(st.cats)
+				st.cursor, st.offset = 0, 0
+			case 'D':
+				st.catIdx = (st.catIdx + len(st.cats) - 1) % len(st.cats)
+				st.cursor, st.offset = 0, 0
+			}
+		default:
+			if b >= 32 && b < 127 {
+				st.filter += string(b)
+				st.cursor, st.offset = 0, 0
+			}
+		}
+		st.rebuild()
+	}
+}
+
+// 根据当前分类、过滤条件和展开状态重新生成行
+func (st *tuiState) rebuild() {
+	set := st.da.categorySet(st.cats[st.catIdx].Key)
+	pkgs := make([]string, 0, len(set))
+	for pkg := range set {
+		if strings.Contains(pkg, st.filter) {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	sort.Strings(pkgs)
+
+	st.rows = st.rows[:0]
+	for _, pkg := range pkgs {
+		st.appendRows(pkg, 0, map[string]bool{})
+	}
+
+	if st.cursor >= len(st.rows) {
+		st.cursor = len(st.rows) - 1
+	}
+	if st.cursor < 0 {
+		st.cursor = 0
+	}
+}
+
+// 追加包及其展开的导入，path 用于避免循环展开
+func (st *tuiState) appendRows(pkg string, level int, path map[string]bool) {
+	st.rows = append(st.rows, tuiRow{pkg: pkg, level: level})
+	if !st.expanded[pkg] || path[pkg] {
+		return
+	}
+	path[pkg] = true
+	defer delete(path, pkg)
+	children := make([]string, 0, len(st.da.edges[pkg]))
+	for child := range st.da.edges[pkg] {
+		children = append(children, child)
+	}
+	sort.Strings(children)
+	for _, child := range children {
+		st.appendRows(child, level+1, path)
+	}
+}
+
+// 绘制界面
+func (st *tuiState) render(height int) {
+	var sb strings.Builder
+	sb.WriteString("\033[H\033[2J")
+
+	// 分类标签
+	for i, cat := range st.cats {
+		label := fmt.Sprintf(" %s %s (%d) ", cat.Icon, cat.Title, len(st.da.categorySet(cat.Key)))
+		if i == st.catIdx {
+			label = "\033[7m" + label + colorReset
+		}
+		sb.WriteString(label)
+	}
+	sb.WriteString("\r\n")
+	fmt.Fprintf(&sb, "过滤: %s█\r\n", st.filter)
+	sb.WriteString("Tab/←→ 切换分类  ↑↓ 移动  Enter 展开内部包  Ctrl+U 清空过滤  Ctrl+C 退出\r\n\r\n")
+
+	listHeight := max(height-5, 1)
+	if st.cursor < st.offset {
+		st.offset = st.cursor
+	}
+	if st.cursor >= st.offset+listHeight {
+		st.offset = st.cursor - listHeight + 1
+	}
+
+	if len(st.rows) == 0 {
+		sb.WriteString("  (无匹配的包)\r\n")
+	}
+	for i := st.offset; i < len(st.rows) && i < st.offset+listHeight; i++ {
+		row := st.rows[i]
+		marker := "  "
+		if st.da.internal[row.pkg] && len(st.da.edges[row.pkg]) > 0 {
+			marker = "▸ "
+			if st.expanded[row.pkg] {
+				marker = "▾ "
+			}
+		}
+		line := strings.Repeat("    ", row.level) + marker + colorize(row.pkg, st.da.categoryOf(row.pkg).Color, true)
+		if i == st.cursor {
+			line = "\033[7m" + line + colorReset
+		}
+		sb.WriteString(line + "\r\n")
+	}
+	fmt.Print(sb.String())
+}
+
+// 通过 stty 将终端切换为原始模式，返回恢复函数
+func enableRawMode() (func(), error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() { stty(strings.TrimSpace(saved)) }, nil
+}
+
+// 获取终端高度，失败时默认 24 行
+func terminalHeight() int {
+	out, err := stty("size")
+	if err != nil {
+		return 24
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 24
+	}
+	rows, err := strconv.Atoi(fields[0])
+	if err != nil || rows <= 0 {
+		return 24
+	}
+	return rows
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// 判断遍历项目时是否跳过该目录: vendor、node_modules、testdata 以及以 . 或 _ 开头的目录
+func skipSourceDir(name string) bool {
+	return name == "vendor" || name == "node_modules" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// 枚举项目内的源码目录 (含项目根目录)，跳过 skipSourceDir 中的目录和嵌套模块
+func (da *DependencyAnalyzer) projectDirs() ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(da.projectPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != da.projectPath {
+			if skipSourceDir(d.Name()) || fileExists(filepath.Join(p, "go.mod")) {
+				return filepath.SkipDir
+			}
+		}
+		dirs = append(dirs, p)
+		return nil
+	})
+	return dirs, err
+}
+
+// 枚举项目内所有包含非测试 .go 文件的包
+func (da *DependencyAnalyzer) projectPackages() ([]string, error) {
+	dirs, err := da.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []string
+	for _, dir := range dirs {
+		if len(goFilesInDir(dir, false)) > 0 {
+			pkgs = append(pkgs, da.pkgOfFile(filepath.Join(dir, "x.go")))
+		}
+	}
+	return pkgs, nil
+}
+
+// 项目内所有包的 .go 文件，作为分析整个模块时的入口 (-all-files 时包含测试文件)
+func (da *DependencyAnalyzer) projectFiles() ([]string, error) {
+	dirs, err := da.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, dir := range dirs {
+		files = append(files, da.buildFiles(goFilesInDir(dir, da.allFiles))...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s 中没有 .go 文件", da.projectPath)
+	}
+	return files, nil
+}
+
+// 查找从入口文件出发深度分析后仍未到达的内部包
+func (da *DependencyAnalyzer) findUnreachable(entries []string) ([]string, error) {
+	all, err := da.projectPackages()
+	if err != nil {
+		return nil, err
+	}
+	reached := make(map[string]bool)
+	for _, pkg := range da.entryPkgs(entries) {
+		reached[pkg] = true
+	}
+	for pkg := range da.depths {
+		reached[pkg] = true
+	}
+	var unreachable []string
+	for _, pkg := range all {
+		if !reached[pkg] && !da.isExcluded(pkg) {
+			unreachable = append(unreachable, pkg)
+		}
+	}
+	return unreachable, nil
+}
+
+// 判断内部包是否是 package main，目录中没有 (非测试) .go 文件时不是
+func (da *DependencyAnalyzer) isMainPackage(pkg string) bool {
+	files := goFilesInDir(da.pkgDir(pkg), false)
+	if len(files) == 0 {
+		return false
+	}
+	name, err := da.packageName(files[0])
+	return err == nil && name == "main"
+}
+
+// 打印无法从入口到达的内部包，package main 单独标注 (通常是未指定为入口的命令)
+func (da *DependencyAnalyzer) printUnreachable(pkgs []string, opts printOptions) {
+	printSectionHeader(fmt.Sprintf("无法从入口到达的内部包 (%d)", len(pkgs)), opts.quiet)
+	if len(pkgs) == 0 {
+		fmt.Println("所有内部包都可以从入口到达")
+	}
+	for _, pkg := range pkgs {
+		if da.isMainPackage(pkg) {
+			fmt.Printf("  %s (package main，未作为入口)\n", pkg)
+		} else {
+			fmt.Printf("  %s\n", pkg)
+		}
+	}
+	if len(pkgs) > 0 {
+		fmt.Println("提示: 可能是无用代码，也可能只被测试或未指定的入口使用，删除前请确认")
+	}
+	printSectionFooter(opts.quiet)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 打印无法解析到源文件的内部包，通常是未生成的代码或重构遗留的导入
+func (da *DependencyAnalyzer) printUnresolved() {
+	fmt.Println()
+	fmt.Printf("⚠️  无法解析的内部包 (%d):\n", len(da.unresolved))
+	for _, pkg := range sortedKeys(da.unresolved) {
+		fmt.Printf("  %s (%s)\n", pkg, da.relPath(da.pkgDir(pkg)))
+	}
+}
+
+// 返回集合中排序后的元素
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// 构建信息，可在构建时通过 -ldflags 注入:
+//
+//	go build -ldflags "-X main.version=v1.2.0 -X main.commit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/check_deps
+//
+// 未注入时从 runtime/debug.ReadBuildInfo 读取 go install 的模块版本和 go build 记录的 VCS 信息 (提交号和提交时间)
+var (
+	version   = ""
+	commit    = ""
+	buildDate = ""
+)
+
+// 工具的构建信息
+type buildInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	CommitAt  string // 提交时间
+	Modified  bool   // 构建时工作区有未提交的改动
+	GoVersion string
+}
+
+// 汇总构建信息，-ldflags 注入的值优先
+func readBuildInfo() buildInfo {
+	info := buildInfo{Version: version, Commit: commit, BuildDate: buildDate, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				info.CommitAt = s.Value
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// 打印版本和构建信息
+func printVersion() {
+	info := readBuildInfo()
+	orUnknown := func(s string) string {
+		if s == "" {
+			return "未知"
+		}
+		return s
+	}
+	fmt.Printf("check_deps %s\n", info.Version)
+	commitLine := orUnknown(info.Commit)
+	if info.Modified {
+		commitLine += " (含未提交的改动)"
+	}
+	fmt.Printf("提交: %s\n", commitLine)
+	if info.CommitAt != "" {
+		fmt.Printf("提交时间: %s\n", info.CommitAt)
+	}
+	fmt.Printf("构建时间: %s\n", orUnknown(info.BuildDate))
+	fmt.Printf("Go: %s %s/%s\n", info.GoVersion, runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 查询模块代理的并发数
+const proxyConcurrency = 8
+
+// 伪版本中的时间戳，如 v0.0.0-20200101120000-abcdef123456
+var pseudoVersionRe = regexp.MustCompile(`(?:^|[.-])(\d{14})-[0-9a-f]{12}(?:\+incompatible)?$`)
+
+// go.mod 中 require 的版本及其发布时间
+type moduleRelease struct {
+	Path     string
+	Version  string
+	Indirect bool
+	Time     time.Time // 零值表示未知
+	Source   string    // 时间来源: 模块缓存 | 模块代理 | 伪版本
+}
+
+// 模块代理的 .info 响应
+type versionInfo struct {
+	Version string
+	Time    time.Time
+}
+
+// 第一个可用的模块代理地址，GOPROXY 为 off 或只有 direct 时返回空
+func moduleProxy() string {
+	env := os.Getenv("GOPROXY")
+	if env == "" {
+		return "https://proxy.golang.org"
+	}
+	for _, p := range strings.FieldsFunc(env, func(r rune) bool { return r == ',' || r == '|' }) {
+		switch p = strings.TrimSpace(p); p {
+		case "off":
+			return ""
+		case "direct", "":
+			continue
+		default:
+			return strings.TrimSuffix(p, "/")
+		}
+	}
+	return ""
+}
+
+// 从伪版本中解析提交时间
+func pseudoVersionTime(version string) (time.Time, bool) {
+	m := pseudoVersionRe.FindStringSubmatch(version)
+	if m == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("20060102150405", m[1])
+	return t, err == nil
+}
+
+// 查询版本的发布时间: 依次尝试模块缓存、模块代理和伪版本
+func (da *DependencyAnalyzer) releaseTime(proxy, path, version string) (time.Time, string) {
+	infoPath := escapeModulePath(path) + "/@v/" + escapeModulePath(version) + ".info"
+
+	if data, err := os.ReadFile(filepath.Join(da.modCacheDir(), "cache", "download", filepath.FromSlash(infoPath))); err == nil {
+		var info versionInfo
+		if json.Unmarshal(data, &info) == nil && !info.Time.IsZero() {
+			return info.Time, "模块缓存"
+		}
+	}
+
+	if proxy != "" {
+		if resp, err := da.net.get(proxy + "/" + infoPath); err == nil {
+			var info versionInfo
+			ok := resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&info) == nil
+			resp.Body.Close()
+			if ok && !info.Time.IsZero() {
+				return info.Time, "模块代理"
+			}
+		}
+	}
+
+	if t, ok := pseudoVersionTime(version); ok {
+		return t, "伪版本"
+	}
+	return time.Time{}, ""
+}
+
+// 查询 go.mod 中所有 require 版本的发布时间，按时间从旧到新排序，未知的排在最后
+func (da *DependencyAnalyzer) moduleReleases() []moduleRelease {
+	proxy := moduleProxy()
+
+	releases := make([]moduleRelease, len(da.goMod.Requires))
+	sem := make(chan struct{}, proxyConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range da.goMod.Requires {
+		releases[i] = moduleRelease{Path: req.Path, Version: req.Version, Indirect: req.Indirect}
+		wg.Add(1)
+		go func(r *moduleRelease) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			r.Time, r.Source = da.releaseTime(proxy, r.Path, r.Version)
+		}(&releases[i])
+	}
+	wg.Wait()
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		ti, tj := releases[i].Time, releases[j].Time
+		if ti.IsZero() != tj.IsZero() {
+			return tj.IsZero()
+		}
+		return ti.Before(tj)
+	})
+	return releases
+}
+
+// 打印依赖版本的发布时间，标记超过 staleMonths 个月的版本
+func (da *DependencyAnalyzer) printVersionSummary(staleMonths int, opts printOptions) {
+	releases := da.moduleReleases()
+	cutoff := time.Now().AddDate(0, -staleMonths, 0)
+
+	printSectionHeader("依赖版本时间", opts.quiet)
+	if da.net.offline {
+		fmt.Println("离线模式 (-offline): 未查询模块代理，只使用模块缓存和伪版本中的时间")
+	}
+	if len(releases) == 0 {
+		fmt.Println("go.mod 中没有 require 的模块")
+		printSectionFooter(opts.quiet)
+		return
+	}
+
+	var known []moduleRelease
+	stale, unknown := 0, 0
+	for _, r := range releases {
+		name := r.Path + "@" + r.Version
+		if r.Indirect {
+			name += " // indirect"
+		}
+		if r.Time.IsZero() {
+			unknown++
+			fmt.Printf("  未知        %s\n", name)
+			continue
+		}
+		known = append(known, r)
+		mark := ""
+		if r.Time.Before(cutoff) {
+			stale++
+			mark = fmt.Sprintf("  ⚠️ 超过 %d 个月", staleMonths)
+		}
+		if opts.verbose {
+			mark += " (" + r.Source + ")"
+		}
+		fmt.Printf("  %-10s  %s%s\n", r.Time.Format("2006-01-02"), name, mark)
+	}
+
+	if len(known) > 0 {
+		oldest, newest := known[0], known[len(known)-1]
+		fmt.Printf("最旧: %s@%s (%s)\n", oldest.Path, oldest.Version, oldest.Time.Format("2006-01-02"))
+		fmt.Printf("最新: %s@%s (%s)\n", newest.Path, newest.Version, newest.Time.Format("2006-01-02"))
+	}
+	fmt.Printf("超过 %d 个月的版本: %d 个\n", staleMonths, stale)
+	if unknown > 0 {
+		fmt.Printf("无法确定发布时间: %d 个 (模块缓存中没有且模块代理不可用、已离线或无此版本，可先执行 go mod download)\n", unknown)
+	}
+	printSectionFooter(opts.quiet)
+}
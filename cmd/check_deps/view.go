@@ -0,0 +1,63 @@
+package main
+
+// 结果视图中的一个包
+type viewPackage struct {
+	Path   string
+	Count  int    // 被导入次数
+	Module string // 外部包所属的模块 (module@version)，内部包为空
+	Direct bool   // 入口文件直接导入
+}
+
+// 结果视图中的一个分类
+type viewCategory struct {
+	Key      string
+	Title    string
+	Packages []viewPackage
+}
+
+// 分析结果的视图，供 HTML 报告和 -template 使用
+type reportView struct {
+	Entries    []string            // 入口文件 (相对项目根目录)
+	Roots      []string            // 入口文件所在的包，作为导入树的根
+	Categories []viewCategory      // 按 -type 过滤后的分类
+	Edges      map[string][]string // 导入关系: 导入方包 -> 被导入包
+	Report     *Report             // 各分类的包列表和统计，与 -format json 相同
+	Deep       bool
+}
+
+// 生成分析结果的视图
+func (da *DependencyAnalyzer) buildView(entries []string, opts printOptions) reportView {
+	data := reportView{
+		Roots:  da.entryPkgs(entries),
+		Edges:  make(map[string][]string),
+		Report: da.buildReport(opts.sortBy),
+		Deep:   opts.deep,
+	}
+	for _, entry := range entries {
+		data.Entries = append(data.Entries, da.relPath(entry))
+	}
+	for _, cat := range append(da.categories(), categoryInfo{Key: "cgo", Title: "cgo"}) {
+		if opts.filterType != "all" && opts.filterType != cat.Key {
+			continue
+		}
+		hc := viewCategory{Key: cat.Key, Title: cat.Title}
+		for _, pkg := range da.sortedSet(da.categorySet(cat.Key), opts.sortBy) {
+			p := viewPackage{Path: pkg, Count: da.counts[pkg], Direct: da.direct[pkg]}
+			if da.isExternalPkg(pkg) {
+				if mod, version := da.moduleOfPkg(pkg); version != "" {
+					p.Module = mod + "@" + version
+				} else {
+					p.Module = mod
+				}
+			}
+			hc.Packages = append(hc.Packages, p)
+		}
+		if len(hc.Packages) > 0 {
+			data.Categories = append(data.Categories, hc)
+		}
+	}
+	for _, e := range da.graphEdges("all") {
+		data.Edges[e[0]] = append(data.Edges[e[0]], e[1])
+	}
+	return data
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 违反 internal 可见性规则的导入
+type visibilityViolation struct {
+	From   string
+	To     string
+	Parent string // 允许导入的子树根，为空表示只有标准库可以导入
+	Site   importSite
+}
+
+// 包路径中最后一个 internal 元素的父路径，不含 internal 元素时返回 false
+func internalParent(pkg string) (string, bool) {
+	parts := strings.Split(pkg, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "internal" {
+			return strings.Join(parts[:i], "/"), true
+		}
+	}
+	return "", false
+}
+
+// 按 Go 的规则判断 from 能否导入 to: 包含 internal 元素的包只能被其父目录为根的子树中的包导入
+func canImportInternal(from, to string) (string, bool) {
+	parent, ok := internalParent(to)
+	if !ok {
+		return "", true
+	}
+	if parent == "" {
+		// 标准库的 internal 包只能被标准库导入
+		return "", !strings.Contains(strings.Split(from, "/")[0], ".")
+	}
+	return parent, from == parent || strings.HasPrefix(from, parent+"/")
+}
+
+// 查找违反 internal 可见性规则的导入，按文件和行号排序
+func (da *DependencyAnalyzer) checkInternalVisibility() []visibilityViolation {
+	var violations []visibilityViolation
+	for to, sites := range da.sites {
+		for _, site := range checkedSites(sites) {
+			if parent, ok := canImportInternal(site.From, to); !ok {
+				violations = append(violations, visibilityViolation{From: site.From, To: to, Parent: parent, Site: site})
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Site.File != violations[j].Site.File {
+			return violations[i].Site.File < violations[j].Site.File
+		}
+		return violations[i].Site.Line < violations[j].Site.Line
+	})
+	return violations
+}
+
+// 打印违反 internal 可见性规则的导入
+func (da *DependencyAnalyzer) printVisibilityViolations(violations []visibilityViolation) {
+	fmt.Println()
+	if len(violations) == 0 {
+		fmt.Println("✅ 没有违反 internal 可见性规则的导入")
+		return
+	}
+	fmt.Printf("❌ 违反 internal 可见性规则的导入 (%d):\n", len(violations))
+	for _, v := range violations {
+		allowed := "只允许标准库导入"
+		if v.Parent != "" {
+			allowed = "只允许 " + v.Parent + " 及其子包导入"
+		}
+		fmt.Printf("  %s -> %s (%s)  %s:%d\n", v.From, v.To, allowed, da.relPath(v.Site.File), v.Site.Line)
+	}
+}
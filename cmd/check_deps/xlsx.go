@@ -0,0 +1,181 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 工作簿中的一个工作表，第一行为表头，单元格为 string 或 int
+type xlsxSheet struct {
+	Name string
+	Rows [][]any
+}
+
+// 以 Excel 工作簿 (.xlsx) 输出: 汇总表 (入口和统计) 加上每个分类一个工作表，外部包带有模块和版本列。
+// 只用标准库按 Office Open XML 的最小结构写 zip，字符串直接内联在单元格中
+func (da *DependencyAnalyzer) writeXLSX(w io.Writer, entries []string, opts printOptions) error {
+	view := da.buildView(entries, opts)
+
+	summary := xlsxSheet{Name: "汇总", Rows: [][]any{{"项目", "值"}}}
+	for _, entry := range view.Entries {
+		summary.Rows = append(summary.Rows, []any{"入口", entry})
+	}
+	if view.Deep {
+		summary.Rows = append(summary.Rows, []any{"模式", "深度分析"})
+	} else {
+		summary.Rows = append(summary.Rows, []any{"模式", "浅层分析 (仅直接依赖)"})
+	}
+	for _, cat := range da.categories() {
+		summary.Rows = append(summary.Rows, []any{cat.Title, len(da.categorySet(cat.Key))})
+	}
+	summary.Rows = append(summary.Rows, []any{"总计", view.Report.Stats.Total})
+	sheets := []xlsxSheet{summary}
+
+	for _, cat := range view.Categories {
+		sheet := xlsxSheet{Name: cat.Title, Rows: [][]any{{"包", "模块", "版本", "导入次数", "直接导入", "首次导入"}}}
+		external := cat.Key != "internal" && cat.Key != "stdlib" && cat.Key != "cgo"
+		if !external {
+			sheet.Rows[0] = []any{"包", "导入次数", "直接导入", "首次导入"}
+		}
+		for _, p := range cat.Packages {
+			direct, first := "", ""
+			if p.Direct {
+				direct = "是"
+			}
+			if site, ok := da.firstSite(p.Path); ok {
+				first = fmt.Sprintf("%s:%d", da.relPath(site.File), site.Line)
+			}
+			if external {
+				module, version := da.moduleOfPkg(p.Path)
+				sheet.Rows = append(sheet.Rows, []any{p.Path, module, version, p.Count, direct, first})
+			} else {
+				sheet.Rows = append(sheet.Rows, []any{p.Path, p.Count, direct, first})
+			}
+		}
+		sheets = append(sheets, sheet)
+	}
+	return writeWorkbook(w, sheets)
+}
+
+// 将工作表写成 .xlsx 文件 (zip 包)
+func writeWorkbook(w io.Writer, sheets []xlsxSheet) error {
+	zw := zip.NewWriter(w)
+	add := func(name, content string) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, xml.Header+content)
+		return err
+	}
+
+	var types, rels, list strings.Builder
+	used := make(map[string]bool)
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+		fmt.Fprintf(&list, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheetName(sheet.Name, n, used)), n, n)
+	}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			types.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + list.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels.String() +
+			fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1) +
+			`</Relationships>`},
+		// 样式 1 为表头使用的粗体
+		{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`},
+	}
+	for _, p := range parts {
+		if err := add(p.name, p.content); err != nil {
+			return err
+		}
+	}
+	for i, sheet := range sheets {
+		if err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheetXML(sheet)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// 工作表的 XML: 冻结表头行，字符串使用内联字符串
+func sheetXML(sheet xlsxSheet) string {
+	var b strings.Builder
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+	for r, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		style := ""
+		if r == 0 {
+			style = ` s="1"`
+		}
+		for c, cell := range row {
+			ref := fmt.Sprintf("%s%d", columnName(c), r+1)
+			switch v := cell.(type) {
+			case int:
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%d</v></c>`, ref, style, v)
+			default:
+				if s := fmt.Sprint(v); s != "" {
+					fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t>%s</t></is></c>`, ref, style, xmlEscape(s))
+				}
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// 列序号 (从 0 开始) 对应的列名: A..Z、AA..
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// 工作表名称: 去掉 Excel 不允许的字符并限制在 31 个字符内，重名时加上序号
+func sheetName(name string, n int, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" || used[name] {
+		name = fmt.Sprintf("Sheet%d", n)
+	}
+	used[name] = true
+	return name
+}
+
+// 转义 XML 文本和属性值
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}